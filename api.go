@@ -0,0 +1,222 @@
+package edgegap
+
+import (
+	"context"
+	"iter"
+)
+
+// DeploymentsAPI is implemented by DeploymentsService, see EdgegapClient.Deployments.
+type DeploymentsAPI interface {
+	Create(ctx context.Context, data *DeployementCreatePayload) (*Response[DeploymentCreateResponse], error)
+	Logs(ctx context.Context, requestId string) (*Response[DeploymentContainerLogs], error)
+	List(ctx context.Context) (*Response[ResponseBody[Deployment]], error)
+	ListPage(ctx context.Context, params PaginationParams) (*Response[ResponseBody[Deployment]], error)
+	Iterate(ctx context.Context) iter.Seq2[Deployment, error]
+	BulkDelete(ctx context.Context, filters []Filter) (*Response[DeploymentBulkDelete], error)
+	Stop(ctx context.Context, requestId string) (*Response[DeploymentStopResponse], error)
+	SelfStop(ctx context.Context, deleteURL string, deleteToken string) (*Response[DeploymentStopResponse], error)
+	SelfContext(ctx context.Context, contextURL string, contextToken string) (*Response[DeploymentInfo], error)
+	Update(ctx context.Context, requestId string, isJoinableSession bool) (*Response[DeploymentUpdateResponse], error)
+	Available(ctx context.Context, data DeploymentAvailableSocketPayload) (*Response[ResponseBody[Deployment]], error)
+	Get(ctx context.Context, request_id string) (*Response[DeploymentInfo], error)
+	CreateFrom(ctx context.Context, request *DeploymentRequest) (*Response[DeploymentCreateResponse], error)
+}
+
+// SessionsAPI is implemented by SessionsService, see EdgegapClient.Sessions.
+type SessionsAPI interface {
+	Create(ctx context.Context, session *SessionCreate) (*Response[SessionCreateRes], error)
+	Delete(ctx context.Context, id string) (*Response[SessionDeleteRes], error)
+	Get(ctx context.Context, id string) (*Response[Session], error)
+	List(ctx context.Context) (*Response[ResponseBody[Session]], error)
+	ListPage(ctx context.Context, params PaginationParams) (*Response[ResponseBody[Session]], error)
+	Iterate(ctx context.Context) iter.Seq2[Session, error]
+	BulkDelete(ctx context.Context, filters []Filter) (*Response[SessionBulkDeleteRes], error)
+}
+
+// SessionUsersAPI is implemented by SessionUsersService, see EdgegapClient.Sessions.Users.
+type SessionUsersAPI interface {
+	Add(ctx context.Context, id string, ips []string) (*Response[SessionUserRes], error)
+	Remove(ctx context.Context, id string, ips []string) (*Response[SessionUserRes], error)
+	List(ctx context.Context, id string) (*Response[SessionUserRes], error)
+}
+
+// ApplicationsAPI is implemented by ApplicationsService, see EdgegapClient.Applications.
+type ApplicationsAPI interface {
+	Create(ctx context.Context, application ApplicationCreate) (*Response[Application], error)
+	Update(ctx context.Context, name string, application ApplicationCreate) (*Response[Application], error)
+	Delete(ctx context.Context, name string) (*Response[map[string]interface{}], error)
+	Get(ctx context.Context, name string) (*Response[Application], error)
+	List(ctx context.Context) (*Response[ApplicationList], error)
+}
+
+// ApplicationVersionsAPI is implemented by ApplicationVersionsService, see EdgegapClient.Applications.Versions.
+type ApplicationVersionsAPI interface {
+	Create(ctx context.Context, appName string, version ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error)
+	Delete(ctx context.Context, appName string, version string) (*Response[map[string]interface{}], error)
+	Get(ctx context.Context, appName string, version string) (*Response[ApplicationVersion], error)
+	Update(ctx context.Context, appName string, version string, data ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error)
+	List(ctx context.Context, appName string) (*Response[ApplicationVersionList], error)
+}
+
+// ApplicationACLAPI is implemented by ApplicationACLService, see EdgegapClient.Applications.Versions.ACL.
+type ApplicationACLAPI interface {
+	Create(ctx context.Context, appName string, version string, data ApplicationACL) (*Response[ApplicationACLCreateResponse], error)
+	List(ctx context.Context, appName string, version string) (*Response[ApplicationACLEntries], error)
+	Delete(ctx context.Context, appName string, version string, entryId string) (*Response[ApplicationACLCreateResponse], error)
+	Get(ctx context.Context, appName string, version string, entryId string) (*Response[ApplicationACL], error)
+}
+
+// FleetsAPI is implemented by FleetsService, see EdgegapClient.Fleets.
+type FleetsAPI interface {
+	Create(ctx context.Context, payload FleetCreatePayload) (*Response[Fleet], error)
+	Get(ctx context.Context, name string) (*Response[Fleet], error)
+	Update(ctx context.Context, name string, payload FleetCreatePayload) (*Response[Fleet], error)
+	Delete(ctx context.Context, name string) (*Response[interface{}], error)
+	List(ctx context.Context) (*Response[FleetList], error)
+	ListPage(ctx context.Context, params PaginationParams) (*Response[FleetList], error)
+	Iterate(ctx context.Context) iter.Seq2[Fleet, error]
+	Link(ctx context.Context, fleet, app, version string) (*Response[FleetApplication], error)
+	Unlink(ctx context.Context, fleet, app, version string) (*Response[interface{}], error)
+}
+
+// MatchmakerAPI is implemented by MatchmakerService, see EdgegapClient.Matchmaker.
+type MatchmakerAPI interface {
+	Create(ctx context.Context, name string) (*Response[Matchmaker], error)
+	Update(ctx context.Context, name string, newName string) (*Response[Matchmaker], error)
+	Delete(ctx context.Context, name string) (*Response[interface{}], error)
+	Get(ctx context.Context, name string) (*Response[Matchmaker], error)
+	List(ctx context.Context) (*Response[MatchmakerListRes], error)
+}
+
+// MatchmakerComponentsAPI is implemented by MatchmakerComponentsService, see EdgegapClient.Matchmaker.Components.
+type MatchmakerComponentsAPI interface {
+	Create(ctx context.Context, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error)
+	Update(ctx context.Context, name string, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error)
+	Delete(ctx context.Context, name string) (*Response[map[string]string], error)
+	Get(ctx context.Context, name string) (*Response[MatchmakerComponent], error)
+	List(ctx context.Context) (*Response[MatchmakerComponentListRes], error)
+}
+
+// MatchmakerEnvsAPI is implemented by MatchmakerEnvsService, see EdgegapClient.Matchmaker.Components.Envs.
+type MatchmakerEnvsAPI interface {
+	Create(ctx context.Context, name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error)
+	Update(ctx context.Context, name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error)
+	Delete(ctx context.Context, name string, env string) (*Response[map[string]string], error)
+	Get(ctx context.Context, name string, env string) (*Response[MatchmakerEnvRes], error)
+	List(ctx context.Context, name string) (*Response[MatchmakerEnvListRes], error)
+}
+
+// MatchmakerReleasesAPI is implemented by MatchmakerReleasesService, see EdgegapClient.Matchmaker.Releases.
+type MatchmakerReleasesAPI interface {
+	Create(ctx context.Context, name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error)
+	Update(ctx context.Context, name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error)
+	Delete(ctx context.Context, name string, version string) (*Response[interface{}], error)
+	Get(ctx context.Context, name string, version string) (*Response[MatchmakerRelease], error)
+	List(ctx context.Context, name string) (*Response[MatchmakerReleaseListRes], error)
+}
+
+// MatchmakerManagedReleasesAPI is implemented by MatchmakerManagedReleasesService, see EdgegapClient.Matchmaker.ManagedReleases.
+type MatchmakerManagedReleasesAPI interface {
+	Create(ctx context.Context, name string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error)
+	Update(ctx context.Context, name string, releaseVersion string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error)
+	Delete(ctx context.Context, name string, releaseVersion string) (*Response[interface{}], error)
+	Get(ctx context.Context, name string, releaseVersion string) (*Response[MatchmakerManagedRelease], error)
+}
+
+// MatchmakerReleaseConfigsAPI is implemented by MatchmakerReleaseConfigsService, see EdgegapClient.Matchmaker.ReleaseConfigs.
+type MatchmakerReleaseConfigsAPI interface {
+	Create(ctx context.Context, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error)
+	Update(ctx context.Context, name string, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error)
+	Delete(ctx context.Context, name string) (*Response[interface{}], error)
+	Get(ctx context.Context, name string) (*Response[MatchmakerReleaseConfig], error)
+	List(ctx context.Context) (*Response[MatchmakerReleaseConfigListRes], error)
+}
+
+// MetricsAPI is implemented by MetricsService, see EdgegapClient.Metrics.
+type MetricsAPI interface {
+	Get(ctx context.Context, id string, filter MetricsFilter) (*Response[Metrics], error)
+}
+
+// TelemetryAPI is implemented by TelemetryService, see EdgegapClient.Telemetry.
+type TelemetryAPI interface {
+	Create(ctx context.Context, payload TelemetryCreate) (*Response[TelemetryCreateRes], error)
+	Get(ctx context.Context, id string) (*Response[Telemetry], error)
+}
+
+// LocationsAPI is implemented by LocationsService, see EdgegapClient.Locations.
+type LocationsAPI interface {
+	List(ctx context.Context, filters LocationFilters) (*Response[LocationListRes], error)
+	Beacons(ctx context.Context) (*Response[LocationBeaconRes], error)
+}
+
+// IPAPI is implemented by IPService, see EdgegapClient.IP.
+type IPAPI interface {
+	Get(ctx context.Context) (*Response[PublicIPResponse], error)
+	Lookup(ctx context.Context, ip string) (*Response[IPInformation], error)
+	LookupBulk(ctx context.Context, payload IPBulkInfoPayload) (*Response[IPBulkInfo], error)
+}
+
+// API regroups the services of a client behind their interfaces, see EdgegapClient.API.
+// Depend on the narrowest interface you need.
+type API struct {
+	Deployments               DeploymentsAPI               // See EdgegapClient.Deployments
+	Sessions                  SessionsAPI                  // See EdgegapClient.Sessions
+	SessionUsers              SessionUsersAPI              // See EdgegapClient.Sessions.Users
+	Applications              ApplicationsAPI              // See EdgegapClient.Applications
+	ApplicationVersions       ApplicationVersionsAPI       // See EdgegapClient.Applications.Versions
+	ApplicationACL            ApplicationACLAPI            // See EdgegapClient.Applications.Versions.ACL
+	Fleets                    FleetsAPI                    // See EdgegapClient.Fleets
+	Matchmaker                MatchmakerAPI                // See EdgegapClient.Matchmaker
+	MatchmakerComponents      MatchmakerComponentsAPI      // See EdgegapClient.Matchmaker.Components
+	MatchmakerEnvs            MatchmakerEnvsAPI            // See EdgegapClient.Matchmaker.Components.Envs
+	MatchmakerReleases        MatchmakerReleasesAPI        // See EdgegapClient.Matchmaker.Releases
+	MatchmakerManagedReleases MatchmakerManagedReleasesAPI // See EdgegapClient.Matchmaker.ManagedReleases
+	MatchmakerReleaseConfigs  MatchmakerReleaseConfigsAPI  // See EdgegapClient.Matchmaker.ReleaseConfigs
+	Metrics                   MetricsAPI                   // See EdgegapClient.Metrics
+	Telemetry                 TelemetryAPI                 // See EdgegapClient.Telemetry
+	Locations                 LocationsAPI                 // See EdgegapClient.Locations
+	IP                        IPAPI                        // See EdgegapClient.IP
+}
+
+// Returns the services of the client behind their interfaces.
+func (e *EdgegapClient) API() API {
+	return API{
+		Deployments:               e.Deployments,
+		Sessions:                  e.Sessions,
+		SessionUsers:              e.Sessions.Users,
+		Applications:              e.Applications,
+		ApplicationVersions:       e.Applications.Versions,
+		ApplicationACL:            e.Applications.Versions.ACL,
+		Fleets:                    e.Fleets,
+		Matchmaker:                e.Matchmaker,
+		MatchmakerComponents:      e.Matchmaker.Components,
+		MatchmakerEnvs:            e.Matchmaker.Components.Envs,
+		MatchmakerReleases:        e.Matchmaker.Releases,
+		MatchmakerManagedReleases: e.Matchmaker.ManagedReleases,
+		MatchmakerReleaseConfigs:  e.Matchmaker.ReleaseConfigs,
+		Metrics:                   e.Metrics,
+		Telemetry:                 e.Telemetry,
+		Locations:                 e.Locations,
+		IP:                        e.IP,
+	}
+}
+
+var (
+	_ DeploymentsAPI               = (*DeploymentsService)(nil)
+	_ SessionsAPI                  = (*SessionsService)(nil)
+	_ SessionUsersAPI              = (*SessionUsersService)(nil)
+	_ ApplicationsAPI              = (*ApplicationsService)(nil)
+	_ ApplicationVersionsAPI       = (*ApplicationVersionsService)(nil)
+	_ ApplicationACLAPI            = (*ApplicationACLService)(nil)
+	_ FleetsAPI                    = (*FleetsService)(nil)
+	_ MatchmakerAPI                = (*MatchmakerService)(nil)
+	_ MatchmakerComponentsAPI      = (*MatchmakerComponentsService)(nil)
+	_ MatchmakerEnvsAPI            = (*MatchmakerEnvsService)(nil)
+	_ MatchmakerReleasesAPI        = (*MatchmakerReleasesService)(nil)
+	_ MatchmakerManagedReleasesAPI = (*MatchmakerManagedReleasesService)(nil)
+	_ MatchmakerReleaseConfigsAPI  = (*MatchmakerReleaseConfigsService)(nil)
+	_ MetricsAPI                   = (*MetricsService)(nil)
+	_ TelemetryAPI                 = (*TelemetryService)(nil)
+	_ LocationsAPI                 = (*LocationsService)(nil)
+	_ IPAPI                        = (*IPService)(nil)
+)
@@ -5,9 +5,9 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"net/http"
 )
 
 type ApplicationSessionKind string
@@ -115,128 +115,192 @@ type ApplicationVersion struct {
 	BuildType          BuildType                 `json:"build_type,omitempty"`                       // Available Build Types: Production or Development
 }
 
+// ApplicationsService manages the applications, see EdgegapClient.Applications.
+type ApplicationsService struct {
+	client *EdgegapClient
+
+	Versions *ApplicationVersionsService // Versions of the applications
+}
+
+// ApplicationVersionsService manages the application versions, see ApplicationsService.Versions.
+type ApplicationVersionsService struct {
+	client *EdgegapClient
+
+	ACL *ApplicationACLService // Access control lists of the application versions
+}
+
+// ApplicationACLService manages the access control lists of the application versions, see ApplicationVersionsService.ACL.
+type ApplicationACLService struct {
+	client *EdgegapClient
+}
+
 // Create an application that will regroup application versions.
-func (e *EdgegapClient) ApplicationCreate(application ApplicationCreate) (*Response[Application], error) {
+func (s *ApplicationsService) Create(ctx context.Context, application ApplicationCreate) (*Response[Application], error) {
 	var response Application
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(application).Post("/app")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationCreate",
+		method:    http.MethodPost,
+		path:      "/app",
+		body:      application,
 	}, &response)
 }
 
 // Update an application with new information.
-func (e *EdgegapClient) ApplicationUpdate(name string, application ApplicationCreate) (*Response[Application], error) {
+func (s *ApplicationsService) Update(ctx context.Context, name string, application ApplicationCreate) (*Response[Application], error) {
 	var response Application
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(application).Patch(fmt.Sprintf("/app/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationUpdate",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/app/%s", name),
+		params:    map[string]string{paramAppName: name},
+		body:      application,
 	}, &response)
 }
 
 // Delete an application and all its current versions.
-func (e *EdgegapClient) ApplicationDelete(name string) (*Response[map[string]interface{}], error) {
+func (s *ApplicationsService) Delete(ctx context.Context, name string) (*Response[map[string]interface{}], error) {
 	var response map[string]interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/app/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationDelete",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/app/%s", name),
+		params:    map[string]string{paramAppName: name},
 	}, &response)
 }
 
 // Retrieve an application and its information.
-func (e *EdgegapClient) Application(name string) (*Response[Application], error) {
+func (s *ApplicationsService) Get(ctx context.Context, name string) (*Response[Application], error) {
 	var response Application
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/app/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "Application",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/app/%s", name),
+		params:    map[string]string{paramAppName: name},
 	}, &response)
 }
 
 // Create an application version associated with an application. The version contains all the specifications to create a deployment.
-func (e *EdgegapClient) ApplicationCreateVersion(appName string, version ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error) {
+func (s *ApplicationVersionsService) Create(ctx context.Context, appName string, version ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error) {
 	var response ApplicationVersionCreateResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(version).Post(fmt.Sprintf("/app/%s/version", appName))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationCreateVersion",
+		method:    http.MethodPost,
+		path:      fmt.Sprintf("/app/%s/version", appName),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version.Name},
+		body:      version,
 	}, &response)
 }
 
 // Delete a specific version of an application.
-func (e *EdgegapClient) ApplicationDeleteVersion(appName string, version string) (*Response[map[string]interface{}], error) {
+func (s *ApplicationVersionsService) Delete(ctx context.Context, appName string, version string) (*Response[map[string]interface{}], error) {
 	var response map[string]interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/app/%s/version/%s", appName, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationDeleteVersion",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/app/%s/version/%s", appName, version),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
 	}, &response)
 }
 
 // Retrieve the specifications of an application version.
-func (e *EdgegapClient) ApplicationGetVersion(appName string, version string) (*Response[ApplicationVersion], error) {
+func (s *ApplicationVersionsService) Get(ctx context.Context, appName string, version string) (*Response[ApplicationVersion], error) {
 	var response ApplicationVersion
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/app/%s/version/%s", appName, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationGetVersion",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/app/%s/version/%s", appName, version),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
 	}, &response)
 }
 
 // Update an application version with new specifications.
-func (e *EdgegapClient) ApplicationUpdateVersion(appName string, version string, data ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error) {
+func (s *ApplicationVersionsService) Update(ctx context.Context, appName string, version string, data ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error) {
 	var response ApplicationVersionCreateResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Patch(fmt.Sprintf("/app/%s/version/%s", appName, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationUpdateVersion",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/app/%s/version/%s", appName, version),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
+		body:      data,
 	}, &response)
 }
 
 // Create an access control list entry for an app version. This will allow the specified CIDR to connect to the deployment. The option whitelisting_active must be activated in the application version.
-func (e *EdgegapClient) ApplicationCreateACLEntry(appName string, version string, data ApplicationACL) (*Response[ApplicationACLCreateResponse], error) {
+func (s *ApplicationACLService) Create(ctx context.Context, appName string, version string, data ApplicationACL) (*Response[ApplicationACLCreateResponse], error) {
 	var response ApplicationACLCreateResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(data).Post(fmt.Sprintf("/app/%s/version/%s/whitelist", appName, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationCreateACLEntry",
+		method:    http.MethodPost,
+		path:      fmt.Sprintf("/app/%s/version/%s/whitelist", appName, version),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
+		body:      data,
 	}, &response)
 }
 
 // List all the access control list entries for a specific application version.
-func (e *EdgegapClient) ApplicationACLEntries(appName string, version string) (*Response[ApplicationACLEntries], error) {
+func (s *ApplicationACLService) List(ctx context.Context, appName string, version string) (*Response[ApplicationACLEntries], error) {
 	var response ApplicationACLEntries
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/app/%s/version/%s/whitelist", appName, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationACLEntries",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/app/%s/version/%s/whitelist", appName, version),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
 	}, &response)
 }
 
 // Delete an access control list entry for a specific application version
-func (e *EdgegapClient) ApplicationDeleteACL(appName string, version string, entryId string) (*Response[ApplicationACLCreateResponse], error) {
+func (s *ApplicationACLService) Delete(ctx context.Context, appName string, version string, entryId string) (*Response[ApplicationACLCreateResponse], error) {
 	var response ApplicationACLCreateResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/app/%s/version/%s/whitelist/%s", appName, version, entryId))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationDeleteACL",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/app/%s/version/%s/whitelist/%s", appName, version, entryId),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
 	}, &response)
 }
 
 // Retrieve a specific access control list entry for an application version.
-func (e *EdgegapClient) ApplicationGetACLById(appName string, version string, entryId string) (*Response[ApplicationACL], error) {
+func (s *ApplicationACLService) Get(ctx context.Context, appName string, version string, entryId string) (*Response[ApplicationACL], error) {
 	var response ApplicationACL
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/app/%s/version/%s/whitelist/%s", appName, version, entryId))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationGetACLById",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/app/%s/version/%s/whitelist/%s", appName, version, entryId),
+		params:    map[string]string{paramAppName: appName, paramAppVersion: version},
 	}, &response)
 }
 
 // List all versions of a specific application.
-func (e *EdgegapClient) ApplicationListVersion(appName string) (*Response[ApplicationVersionList], error) {
+func (s *ApplicationVersionsService) List(ctx context.Context, appName string) (*Response[ApplicationVersionList], error) {
 	var response ApplicationVersionList
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/app/%s/versions", appName))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationListVersion",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/app/%s/versions", appName),
+		params:    map[string]string{paramAppName: appName},
 	}, &response)
 }
 
 // List all the applications that you own.
-func (e *EdgegapClient) ApplicationGetList() (*Response[ApplicationList], error) {
+func (s *ApplicationsService) List(ctx context.Context) (*Response[ApplicationList], error) {
 	var response ApplicationList
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/apps")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "ApplicationGetList",
+		method:    http.MethodGet,
+		path:      "/apps",
 	}, &response)
 }
@@ -0,0 +1,94 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestApplicationLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	if _, err := client.ApplicationCreate(ctx, edgegap.ApplicationCreate{Name: "game", IsActive: true}); err != nil {
+		t.Fatalf("ApplicationCreate() error = %v", err)
+	}
+
+	if _, err := client.ApplicationCreate(ctx, edgegap.ApplicationCreate{Name: "game"}); !edgegap.IsConflict(err) {
+		t.Errorf("ApplicationCreate() on an existing app error = %v, want a conflict", err)
+	}
+
+	version := edgegap.ApplicationVersion{Name: "v1", DockerImage: "studio/game", DockerTag: "1.0.0", ReqCPU: 256, ReqMemory: 512}
+
+	if _, err := client.ApplicationCreateVersion(ctx, "game", version); err != nil {
+		t.Fatalf("ApplicationCreateVersion() error = %v", err)
+	}
+
+	version.DockerTag = "1.0.1"
+
+	if _, err := client.ApplicationUpdateVersion(ctx, "game", "v1", version); err != nil {
+		t.Fatalf("ApplicationUpdateVersion() error = %v", err)
+	}
+
+	got, err := client.ApplicationGetVersion(ctx, "game", "v1")
+	if err != nil {
+		t.Fatalf("ApplicationGetVersion() error = %v", err)
+	}
+
+	if got.Data.DockerTag != "1.0.1" {
+		t.Errorf("DockerTag = %q, want %q", got.Data.DockerTag, "1.0.1")
+	}
+
+	versions, err := client.ApplicationListVersion(ctx, "game")
+	if err != nil {
+		t.Fatalf("ApplicationListVersion() error = %v", err)
+	}
+
+	if versions.Data.TotalCount != 1 {
+		t.Errorf("TotalCount = %d, want 1", versions.Data.TotalCount)
+	}
+
+	if _, err := client.ApplicationDelete(ctx, "game"); err != nil {
+		t.Fatalf("ApplicationDelete() error = %v", err)
+	}
+
+	if _, err := client.Application(ctx, "game"); !edgegap.IsNotFound(err) {
+		t.Errorf("Application() after delete error = %v, want not found", err)
+	}
+}
+
+func TestApplicationACL(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	created, err := client.ApplicationCreateACLEntry(ctx, "game", "v1", edgegap.ApplicationACL{CIDR: "10.0.0.0/8", Label: "office"})
+	if err != nil {
+		t.Fatalf("ApplicationCreateACLEntry() error = %v", err)
+	}
+
+	id := created.Data.WhiteListEntry.ID
+
+	entry, err := client.ApplicationGetACLById(ctx, "game", "v1", id)
+	if err != nil {
+		t.Fatalf("ApplicationGetACLById() error = %v", err)
+	}
+
+	if entry.Data.CIDR != "10.0.0.0/8" {
+		t.Errorf("CIDR = %q, want %q", entry.Data.CIDR, "10.0.0.0/8")
+	}
+
+	if _, err := client.ApplicationDeleteACL(ctx, "game", "v1", id); err != nil {
+		t.Fatalf("ApplicationDeleteACL() error = %v", err)
+	}
+
+	entries, err := client.ApplicationACLEntries(ctx, "game", "v1")
+	if err != nil {
+		t.Fatalf("ApplicationACLEntries() error = %v", err)
+	}
+
+	if len(entries.Data.WhitelistEntries) != 0 {
+		t.Errorf("WhitelistEntries = %v, want none", entries.Data.WhitelistEntries)
+	}
+}
@@ -0,0 +1,302 @@
+package edgegap
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EnvVisibility tells if the value of an environment variable is encrypted, see DeploymentRequest.Env.
+type EnvVisibility bool
+
+const (
+	Visible = EnvVisibility(false) // The value is stored as is
+	Hidden  = EnvVisibility(true)  // The value is encrypted during the process of deployment
+)
+
+// Environment variable keys, as accepted by the containers.
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Prefix of the environment variables injected by Edgegap, which can not be overridden.
+const reservedEnvPrefix = "ARBITRIUM_"
+
+// Filter fields selecting the location of a deployment, and if they hold several values per location.
+var locationFilterFields = map[EField]bool{
+	ECity:          false,
+	ECountry:       false,
+	EContinent:     false,
+	ERegion:        false,
+	EAdminDivision: false,
+	ELocationTags:  true,
+}
+
+// DeploymentRequest builds a DeployementCreatePayload, checking it before it is sent.
+//
+//	request := edgegap.NewDeployment("game").
+//		Version("v1").
+//		Players("203.0.113.7", "198.51.100.4").
+//		NearCity("Montreal").
+//		Tag("ranked").
+//		Env("MODE", "ctf", edgegap.Hidden)
+//
+//	res, err := client.Deployments.CreateFrom(ctx, request)
+type DeploymentRequest struct {
+	payload     DeployementCreatePayload
+	hasLocation bool
+}
+
+// Starts a request to deploy the App named app.
+func NewDeployment(app string) *DeploymentRequest {
+	return &DeploymentRequest{payload: DeployementCreatePayload{AppName: app}}
+}
+
+// Sets the App Version to deploy. Without it, the last version created is deployed.
+func (r *DeploymentRequest) Version(version string) *DeploymentRequest {
+	r.payload.VersionName = version
+	return r
+}
+
+// Deploys a public App instead of a private one.
+func (r *DeploymentRequest) PublicApp() *DeploymentRequest {
+	r.payload.IsPublicApp = true
+	return r
+}
+
+// Adds the IPs of the players, used to pick the best location. Can not be mixed with PlayerAt.
+func (r *DeploymentRequest) Players(ips ...string) *DeploymentRequest {
+	r.payload.IpList = append(r.payload.IpList, ips...)
+	return r
+}
+
+// Adds a player with its known location. Can not be mixed with Players.
+func (r *DeploymentRequest) PlayerAt(ip string, latitude, longitude float64) *DeploymentRequest {
+	r.payload.GeoIPList = append(r.payload.GeoIPList, GeoIPList{IP: ip, Latitude: latitude, Longitude: longitude})
+	return r
+}
+
+// Deploys near the given coordinates.
+func (r *DeploymentRequest) Near(latitude, longitude float64) *DeploymentRequest {
+	r.payload.Location = Location{Latitude: latitude, Longitude: longitude}
+	r.hasLocation = true
+	return r
+}
+
+// Restricts the locations to the given cities.
+func (r *DeploymentRequest) NearCity(cities ...string) *DeploymentRequest {
+	return r.Filter(ECity, EAny, cities...)
+}
+
+// Restricts the locations to the given countries.
+func (r *DeploymentRequest) NearCountry(countries ...string) *DeploymentRequest {
+	return r.Filter(ECountry, EAny, countries...)
+}
+
+// Restricts the locations to the given continents.
+func (r *DeploymentRequest) NearContinent(continents ...string) *DeploymentRequest {
+	return r.Filter(EContinent, EAny, continents...)
+}
+
+// Restricts the locations to the given regions.
+func (r *DeploymentRequest) NearRegion(regions ...string) *DeploymentRequest {
+	return r.Filter(ERegion, EAny, regions...)
+}
+
+// Adds a filter on the locations the deployment can be made in.
+func (r *DeploymentRequest) Filter(field EField, filterType EFilterType, values ...string) *DeploymentRequest {
+	r.payload.Filters = append(r.payload.Filters, Filter{Field: field, FilterType: filterType, Values: values})
+	return r
+}
+
+// Sets the algorithm used to select the location.
+func (r *DeploymentRequest) SortStrategy(strategy ESortStrategy) *DeploymentRequest {
+	r.payload.ApSortStrategy = strategy
+	return r
+}
+
+// Skips the telemetry and picks the location from the geolocation of the players only.
+func (r *DeploymentRequest) SkipTelemetry() *DeploymentRequest {
+	r.payload.SkipTelemetry = true
+	return r
+}
+
+// Adds telemetry profiles used to pick the location.
+func (r *DeploymentRequest) TelemetryProfiles(uuids ...string) *DeploymentRequest {
+	r.payload.TelemetryProfileUUIDList = append(r.payload.TelemetryProfileUUIDList, uuids...)
+	return r
+}
+
+// Adds tags to the deployment.
+func (r *DeploymentRequest) Tag(tags ...string) *DeploymentRequest {
+	r.payload.Tags = append(r.payload.Tags, tags...)
+	return r
+}
+
+// Adds an environment variable to the deployment, encrypted when visibility is Hidden.
+func (r *DeploymentRequest) Env(key, value string, visibility ...EnvVisibility) *DeploymentRequest {
+	hidden := len(visibility) > 0 && bool(visibility[0])
+
+	r.payload.EnvVariables = append(r.payload.EnvVariables, EnvVariabls{Key: key, Value: value, IsHidden: hidden})
+	return r
+}
+
+// Sets the URL the deployment status is POSTed to, see WebhookHandler.
+func (r *DeploymentRequest) Webhook(webhookURL string) *DeploymentRequest {
+	r.payload.WebhookURL = webhookURL
+	return r
+}
+
+// Overrides the command of the container.
+func (r *DeploymentRequest) Command(command string) *DeploymentRequest {
+	r.payload.Command = command
+	return r
+}
+
+// Overrides the arguments of the container.
+func (r *DeploymentRequest) Arguments(arguments string) *DeploymentRequest {
+	r.payload.Arguments = arguments
+	return r
+}
+
+// Checks the request, returning a *ValidationError listing every problem found.
+func (r *DeploymentRequest) Validate() error {
+	p := &r.payload
+	problems := &ValidationError{}
+
+	if strings.TrimSpace(p.AppName) == "" {
+		problems.add("app_name", "is required")
+	}
+
+	if len(p.IpList) > 0 && len(p.GeoIPList) > 0 {
+		problems.add("ip_list", "can not be used with geo_ip_list")
+	}
+
+	if len(p.IpList) == 0 && len(p.GeoIPList) == 0 && !r.hasLocation {
+		problems.add("ip_list", "is required when neither geo_ip_list nor location is set")
+	}
+
+	for i, ip := range p.IpList {
+		if net.ParseIP(ip) == nil {
+			problems.add(indexed("ip_list", i), "%q is not a valid IP address", ip)
+		}
+	}
+
+	for i, player := range p.GeoIPList {
+		if net.ParseIP(player.IP) == nil {
+			problems.add(indexed("geo_ip_list", i)+".ip", "%q is not a valid IP address", player.IP)
+		}
+
+		validateCoordinates(problems, indexed("geo_ip_list", i), player.Latitude, player.Longitude)
+	}
+
+	if r.hasLocation {
+		validateCoordinates(problems, "location", p.Location.Latitude, p.Location.Longitude)
+	}
+
+	for i, filter := range p.Filters {
+		validateFilter(problems, indexed("filters", i), filter)
+	}
+
+	if p.ApSortStrategy != "" && p.ApSortStrategy != EBasic && p.ApSortStrategy != EWeighted {
+		problems.add("ap_sort_strategy", "%q is not a known strategy", p.ApSortStrategy)
+	}
+
+	if p.SkipTelemetry && len(p.TelemetryProfileUUIDList) > 0 {
+		problems.add("telemetry_profile_uuid_list", "can not be used with skip_telemetry")
+	}
+
+	for i, tag := range p.Tags {
+		switch {
+		case strings.TrimSpace(tag) == "":
+			problems.add(indexed("tags", i), "is empty")
+		case slices.Index(p.Tags, tag) < i:
+			problems.add(indexed("tags", i), "%q is a duplicate", tag)
+		}
+	}
+
+	keys := map[string]bool{}
+
+	for i, env := range p.EnvVariables {
+		field := indexed("env_vars", i) + ".key"
+
+		switch {
+		case !envKeyPattern.MatchString(env.Key):
+			problems.add(field, "%q is not a valid environment variable name", env.Key)
+		case strings.HasPrefix(strings.ToUpper(env.Key), reservedEnvPrefix):
+			problems.add(field, "%q is reserved to the variables injected by Edgegap", env.Key)
+		case keys[env.Key]:
+			problems.add(field, "%q is a duplicate", env.Key)
+		}
+
+		keys[env.Key] = true
+	}
+
+	if p.WebhookURL != "" {
+		if u, err := url.Parse(p.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems.add("webhook_url", "%q is not an absolute HTTP URL", p.WebhookURL)
+		}
+	}
+
+	return problems.orNil()
+}
+
+// Returns the payload of the request once validated.
+func (r *DeploymentRequest) Build() (*DeployementCreatePayload, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	payload := r.payload
+
+	return &payload, nil
+}
+
+// Create a new deployment from a request, which is validated before being sent.
+func (s *DeploymentsService) CreateFrom(ctx context.Context, request *DeploymentRequest) (*Response[DeploymentCreateResponse], error) {
+	payload, err := request.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Create(ctx, payload)
+}
+
+func validateCoordinates(problems *ValidationError, field string, latitude, longitude float64) {
+	if latitude < -90 || latitude > 90 {
+		problems.add(field+".latitude", "%v is not between -90 and 90", latitude)
+	}
+
+	if longitude < -180 || longitude > 180 {
+		problems.add(field+".longitude", "%v is not between -180 and 180", longitude)
+	}
+}
+
+func validateFilter(problems *ValidationError, field string, filter Filter) {
+	multiple, ok := locationFilterFields[filter.Field]
+
+	if !ok {
+		problems.add(field+".field", "%q can not filter the location of a deployment", filter.Field)
+	}
+
+	switch filter.FilterType {
+	case EAny, ENot:
+	case EAll:
+		// A location has a single city, country... : requiring all of several never matches.
+		if ok && !multiple && len(filter.Values) > 1 {
+			problems.add(field+".filter_type", "%q never matches several values of %q", filter.FilterType, filter.Field)
+		}
+	default:
+		problems.add(field+".filter_type", "%q is not a known filter type", filter.FilterType)
+	}
+
+	if len(filter.Values) == 0 {
+		problems.add(field+".values", "is empty")
+	}
+}
+
+func indexed(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
@@ -0,0 +1,102 @@
+package edgegap_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestDeploymentRequestCreate(t *testing.T) {
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	request := edgegap.NewDeployment("game").
+		Version("v1").
+		Players("203.0.113.7", "2001:db8::1").
+		NearCity("Montreal").
+		Tag("ranked").
+		Env("MODE", "ctf", edgegap.Hidden).
+		Env("MAP", "dust")
+
+	res, err := client.Deployments.CreateFrom(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Deployments.CreateFrom() error = %v", err)
+	}
+
+	if res.Data.RequestID == "" || res.Data.RequestVersion != "v1" {
+		t.Errorf("Deployments.CreateFrom() = %+v, want a deployment of v1", res.Data)
+	}
+
+	var payload edgegap.DeployementCreatePayload
+
+	if err := json.Unmarshal(server.Requests()[0].Body, &payload); err != nil {
+		t.Fatalf("decoding the sent payload : %v", err)
+	}
+
+	if len(payload.EnvVariables) != 2 || !payload.EnvVariables[0].IsHidden || payload.EnvVariables[1].IsHidden {
+		t.Errorf("sent env_vars = %+v, want MODE hidden and MAP visible", payload.EnvVariables)
+	}
+
+	if len(payload.Filters) != 1 || payload.Filters[0].Field != edgegap.ECity || payload.Filters[0].FilterType != edgegap.EAny {
+		t.Errorf("sent filters = %+v, want any city", payload.Filters)
+	}
+}
+
+func TestDeploymentRequestValidation(t *testing.T) {
+	server, client := newTestClient(t)
+
+	request := edgegap.NewDeployment("").
+		Players("not-an-ip").
+		PlayerAt("203.0.113.7", 120, 10).
+		Filter(edgegap.ECity, edgegap.EAll, "Montreal", "Paris").
+		Filter(edgegap.ERequestID, edgegap.EAny, "9f511e17dfa4").
+		SkipTelemetry().
+		TelemetryProfiles("3a2b").
+		Tag("ranked", "ranked").
+		Env("1MODE", "ctf").
+		Env("ARBITRIUM_REQUEST_ID", "spoofed").
+		Webhook("/callbacks")
+
+	_, err := client.Deployments.CreateFrom(context.Background(), request)
+
+	var validationErr *edgegap.ValidationError
+
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Deployments.CreateFrom() error = %v, want a ValidationError", err)
+	}
+
+	want := []string{
+		"app_name",
+		"ip_list",
+		"ip_list[0]",
+		"geo_ip_list[0].latitude",
+		"filters[0].filter_type",
+		"filters[1].field",
+		"telemetry_profile_uuid_list",
+		"tags[1]",
+		"env_vars[0].key",
+		"env_vars[1].key",
+		"webhook_url",
+	}
+
+	if len(validationErr.Problems) != len(want) {
+		t.Fatalf("Problems = %+v, want problems on %v", validationErr.Problems, want)
+	}
+
+	for i, field := range want {
+		if validationErr.Problems[i].Field != field {
+			t.Errorf("Problems[%d] = %+v, want a problem on %s", i, validationErr.Problems[i], field)
+		}
+	}
+
+	if len(server.Requests()) != 0 {
+		t.Errorf("%d requests sent, want none for an invalid request", len(server.Requests()))
+	}
+
+	if err := edgegap.NewDeployment("game").Near(45.5, -73.56).Validate(); err != nil {
+		t.Errorf("Validate() with a location only error = %v", err)
+	}
+}
@@ -0,0 +1,344 @@
+package edgegap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Default number of deployments or sessions a BulkStopPlan may stop.
+const DefaultBulkStopMaxCount = 25
+
+// Default number of stopped deployments or sessions a BulkStopPlan waits for at once.
+const DefaultBulkStopWaitConcurrency = 8
+
+// ErrPlanExecuted is returned when a BulkStopPlan is executed twice.
+var ErrPlanExecuted = errors.New("edgegap: bulk stop plan already executed")
+
+// BulkStopGuardrails are the checks a BulkStopPlan must pass before it can be executed.
+// The tags are the deployment tags, those of the deployment a session is linked to for a session plan.
+type BulkStopGuardrails struct {
+	MaxCount          int      // Maximum number of matches. Defaults to DefaultBulkStopMaxCount, negative means no limit
+	RequiredTags      []string // Tags every match must have
+	ProtectedTags     []string // Tags no match can have
+	AllowEmptyFilters bool     // Allows a plan without filters, which matches everything
+}
+
+// GuardrailError is returned when a BulkStopPlan violating its guardrails is executed.
+type GuardrailError struct {
+	Violations []string // Every guardrail violated
+}
+
+func (e *GuardrailError) Error() string {
+	return "Guardrail Error : " + strings.Join(e.Violations, ", ")
+}
+
+// BulkStopOptions controls how a BulkStopPlan is executed.
+type BulkStopOptions struct {
+	Wait        bool        // Waits until the stopped deployments are terminated, or the stopped sessions are gone
+	WaitOptions WaitOptions // How the stopped deployments or sessions are polled when Wait is set
+	Concurrency int         // Maximum number of deployments or sessions waited for at once. Defaults to DefaultBulkStopWaitConcurrency
+}
+
+// BulkStopPlan is a bulk stop of deployments or sessions, previewed before being executed.
+// See PlanBulkStop and PlanSessionBulkStop.
+// The plan can not be changed once made : its accessors return copies.
+type BulkStopPlan[T FilterSubject] struct {
+	filters    []Filter // Filters of the bulk stop
+	matches    []T      // Deployments or sessions matching the filters when the plan was made
+	violations []string // Guardrails violated by the plan, which can only be executed without any
+
+	stopper  bulkStopper[T]
+	mu       sync.Mutex // Held while the plan is executed
+	executed bool
+}
+
+// BulkStopFailure is a deployment or session of a BulkStopPlan that was not stopped.
+type BulkStopFailure struct {
+	ID  string // Request ID of the deployment, or ID of the session
+	Err error
+}
+
+// BulkStopResult is the outcome of a BulkStopPlan.
+type BulkStopResult struct {
+	Processable []string          // IDs the API accepted to stop
+	Stopped     []string          // IDs seen terminated, or gone for sessions. Only set when waiting
+	Failed      []BulkStopFailure // Matches of the plan that were not stopped
+}
+
+// bulkStopper is how a plan lists, stops and waits for the deployments or sessions.
+type bulkStopper[T FilterSubject] struct {
+	kind       string // Name of what is stopped, for the violations and errors
+	idField    EField // Filter field of the IDs
+	id         func(item T) string
+	list       func(ctx context.Context) iter.Seq2[T, error]
+	bulkDelete func(ctx context.Context, filters []Filter) ([]string, error)
+	wait       func(ctx context.Context, id string, opts WaitOptions) error
+}
+
+// Lists the deployments matching filters, evaluated locally with MatchFilters, and checks them against guardrails.
+// Nothing is stopped until BulkStopPlan.Execute is called. Filters the listed deployments can not be evaluated on,
+// like location filters, are refused with a *FilterError.
+//
+//	plan, err := client.PlanBulkStop(ctx, filters, edgegap.BulkStopGuardrails{ProtectedTags: []string{"production"}})
+//	if err != nil {
+//		return err
+//	}
+//
+//	fmt.Println("stopping", len(plan.Matches()), "deployments")
+//
+//	result, err := plan.Execute(ctx, edgegap.BulkStopOptions{Wait: true})
+func (e *EdgegapClient) PlanBulkStop(ctx context.Context, filters []Filter, guardrails BulkStopGuardrails) (*BulkStopPlan[Deployment], error) {
+	return planBulkStop(ctx, filters, guardrails, bulkStopper[Deployment]{
+		kind:    "deployment",
+		idField: ERequestID,
+		id:      func(deployment Deployment) string { return deployment.RequestID },
+		list:    e.Deployments.Iterate,
+		bulkDelete: func(ctx context.Context, filters []Filter) ([]string, error) {
+			res, err := e.Deployments.BulkDelete(ctx, filters)
+			if err != nil {
+				return nil, err
+			}
+
+			return res.Data.RequestIDs, nil
+		},
+		wait: func(ctx context.Context, id string, opts WaitOptions) error {
+			_, err := e.WaitForStopped(ctx, id, opts)
+			return err
+		},
+	})
+}
+
+// Lists the sessions matching filters like PlanBulkStop, for a bulk stop of sessions executed with Sessions.BulkDelete.
+// The sessions are evaluated with the deployment they are linked to, whose location is not known.
+func (e *EdgegapClient) PlanSessionBulkStop(ctx context.Context, filters []Filter, guardrails BulkStopGuardrails) (*BulkStopPlan[Session], error) {
+	return planBulkStop(ctx, filters, guardrails, bulkStopper[Session]{
+		kind:    "session",
+		idField: ESessionID,
+		id:      func(session Session) string { return session.ID },
+		list:    e.Sessions.Iterate,
+		bulkDelete: func(ctx context.Context, filters []Filter) ([]string, error) {
+			res, err := e.Sessions.BulkDelete(ctx, filters)
+			if err != nil {
+				return nil, err
+			}
+
+			return res.Data.Processable, nil
+		},
+		wait: e.waitForSessionDeleted,
+	})
+}
+
+func planBulkStop[T FilterSubject](ctx context.Context, filters []Filter, guardrails BulkStopGuardrails, stopper bulkStopper[T]) (*BulkStopPlan[T], error) {
+	plan := &BulkStopPlan[T]{filters: slices.Clone(filters), stopper: stopper}
+
+	for item, err := range stopper.list(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := MatchFilters(filters, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			plan.matches = append(plan.matches, item)
+		}
+	}
+
+	plan.violations = checkGuardrails(guardrails, filters, plan.matches, stopper)
+
+	return plan, nil
+}
+
+// Returns the filters of the bulk stop.
+func (p *BulkStopPlan[T]) Filters() []Filter {
+	return slices.Clone(p.filters)
+}
+
+// Returns the deployments or sessions matching the filters when the plan was made.
+func (p *BulkStopPlan[T]) Matches() []T {
+	return slices.Clone(p.matches)
+}
+
+// Returns the guardrails violated by the plan, which can only be executed without any.
+func (p *BulkStopPlan[T]) Violations() []string {
+	return slices.Clone(p.violations)
+}
+
+// Returns the IDs of the matches : request IDs for deployments, session IDs for sessions.
+func (p *BulkStopPlan[T]) IDs() []string {
+	ids := make([]string, len(p.matches))
+
+	for i, item := range p.matches {
+		ids[i] = p.stopper.id(item)
+	}
+
+	return ids
+}
+
+// Stops the matches, returning a *GuardrailError when the plan violates its guardrails.
+// Only the matches previewed are stopped, even if others match the filters since the plan was made.
+// The plan can be executed once the API accepted it : after a failed request, it can be executed again.
+// The wait is controlled by the first opts, if any.
+func (p *BulkStopPlan[T]) Execute(ctx context.Context, opts ...BulkStopOptions) (*BulkStopResult, error) {
+	if len(p.violations) > 0 {
+		return nil, &GuardrailError{Violations: slices.Clone(p.violations)}
+	}
+
+	var options BulkStopOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	result, err := p.execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Wait {
+		p.wait(ctx, result, options)
+	}
+
+	return result, nil
+}
+
+// execute sends the bulk stop, marking the plan executed once the API accepted it.
+func (p *BulkStopPlan[T]) execute(ctx context.Context) (*BulkStopResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.executed {
+		return nil, ErrPlanExecuted
+	}
+
+	result := &BulkStopResult{Processable: []string{}}
+
+	ids := p.IDs()
+	if len(ids) == 0 {
+		p.executed = true
+		return result, nil
+	}
+
+	// Restricting the filters to the previewed matches, the API can not stop more than what was shown.
+	filters := append(slices.Clone(p.filters), Filter{Field: p.stopper.idField, FilterType: EAny, Values: ids})
+
+	processable, err := p.stopper.bulkDelete(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	p.executed = true
+	result.Processable = processable
+
+	for _, id := range ids {
+		if !slices.Contains(result.Processable, id) {
+			result.Failed = append(result.Failed, BulkStopFailure{ID: id, Err: fmt.Errorf("edgegap: %s %s was not processed by the bulk stop", p.stopper.kind, id)})
+		}
+	}
+
+	return result, nil
+}
+
+// wait waits for the processable matches to be stopped, recording each outcome in result.
+func (p *BulkStopPlan[T]) wait(ctx context.Context, result *BulkStopResult, options BulkStopOptions) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkStopWaitConcurrency
+	}
+
+	errs := make([]error, len(result.Processable))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, id := range result.Processable {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			errs[i] = p.stopper.wait(ctx, id, options.WaitOptions)
+		}()
+	}
+
+	wg.Wait()
+
+	for i, id := range result.Processable {
+		if errs[i] != nil {
+			result.Failed = append(result.Failed, BulkStopFailure{ID: id, Err: errs[i]})
+		} else {
+			result.Stopped = append(result.Stopped, id)
+		}
+	}
+}
+
+// waitForSessionDeleted polls a session until the API does not know it anymore.
+func (e *EdgegapClient) waitForSessionDeleted(ctx context.Context, sessionID string, opts WaitOptions) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	policy := opts.policy()
+
+	for poll := 1; ; poll++ {
+		_, err := e.Sessions.Get(ctx, sessionID)
+
+		switch {
+		case IsNotFound(err):
+			return nil
+		case err != nil && !isTransient(err):
+			return err
+		}
+
+		if err := sleepContext(ctx, policy.backoff(poll)); err != nil {
+			return err
+		}
+	}
+}
+
+// checkGuardrails returns the guardrails violated by a plan. The tags are the values of the matches for EDeploymentTag.
+func checkGuardrails[T FilterSubject](g BulkStopGuardrails, filters []Filter, matches []T, stopper bulkStopper[T]) []string {
+	var violations []string
+
+	if len(filters) == 0 && !g.AllowEmptyFilters {
+		violations = append(violations, fmt.Sprintf("no filters, every %s would be stopped", stopper.kind))
+	}
+
+	maxCount := g.MaxCount
+	if maxCount == 0 {
+		maxCount = DefaultBulkStopMaxCount
+	}
+
+	if maxCount > 0 && len(matches) > maxCount {
+		violations = append(violations, fmt.Sprintf("%d %ss matched, more than the maximum of %d", len(matches), stopper.kind, maxCount))
+	}
+
+	for _, item := range matches {
+		tags, _ := item.FilterValues(EDeploymentTag)
+
+		for _, tag := range g.RequiredTags {
+			if !slices.Contains(tags, tag) {
+				violations = append(violations, fmt.Sprintf("%s %s does not have the required tag %q", stopper.kind, stopper.id(item), tag))
+			}
+		}
+
+		for _, tag := range g.ProtectedTags {
+			if slices.Contains(tags, tag) {
+				violations = append(violations, fmt.Sprintf("%s %s has the protected tag %q", stopper.kind, stopper.id(item), tag))
+			}
+		}
+	}
+
+	return violations
+}
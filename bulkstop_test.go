@@ -0,0 +1,201 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+var ranked = []edgegap.Filter{{Field: edgegap.EDeploymentTag, FilterType: edgegap.EAny, Values: []string{"ranked"}}}
+
+func TestBulkStopPlanExecute(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	first := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked"}, CurrentStatus: edgegaptest.StatusReady})
+	second := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked"}, CurrentStatus: edgegaptest.StatusReady})
+	casual := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"casual"}, CurrentStatus: edgegaptest.StatusReady})
+
+	plan, err := client.PlanBulkStop(ctx, ranked, edgegap.BulkStopGuardrails{MaxCount: 2, RequiredTags: []string{"ranked"}})
+	if err != nil {
+		t.Fatalf("PlanBulkStop() error = %v", err)
+	}
+
+	if ids := plan.IDs(); !slices.Equal(ids, []string{first, second}) || len(plan.Violations()) != 0 {
+		t.Fatalf("PlanBulkStop() matched %v with violations %v, want %v", ids, plan.Violations(), []string{first, second})
+	}
+
+	// Matching after the preview, it must not be stopped.
+	late := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked"}, CurrentStatus: edgegaptest.StatusReady})
+
+	result, err := plan.Execute(ctx, edgegap.BulkStopOptions{Wait: true, WaitOptions: fastPolls})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !slices.Equal(result.Processable, []string{first, second}) || !slices.Equal(result.Stopped, []string{first, second}) || len(result.Failed) != 0 {
+		t.Errorf("Execute() = %+v, want %s and %s stopped", result, first, second)
+	}
+
+	for _, id := range []string{casual, late} {
+		if res, err := client.Deployments.Get(ctx, id); err != nil || res.Data.CurrentStatus != edgegaptest.StatusReady {
+			t.Errorf("deployment %s after Execute() = %v, %v, want it still ready", id, res, err)
+		}
+	}
+
+	if _, err := plan.Execute(ctx); !errors.Is(err, edgegap.ErrPlanExecuted) {
+		t.Errorf("second Execute() error = %v, want ErrPlanExecuted", err)
+	}
+}
+
+func TestBulkStopPlanExecuteAfterFailure(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	requestID := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked"}, CurrentStatus: edgegaptest.StatusReady})
+
+	plan, err := client.PlanBulkStop(ctx, ranked, edgegap.BulkStopGuardrails{})
+	if err != nil {
+		t.Fatalf("PlanBulkStop() error = %v", err)
+	}
+
+	server.InjectFault(edgegaptest.Fault{Path: "/deployments/bulk-stop", Status: 400, Times: 1})
+
+	if _, err := plan.Execute(ctx); err == nil || errors.Is(err, edgegap.ErrPlanExecuted) {
+		t.Fatalf("Execute() error = %v, want the API error", err)
+	}
+
+	result, err := plan.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() after a failed request error = %v", err)
+	}
+
+	if !slices.Equal(result.Processable, []string{requestID}) {
+		t.Errorf("Execute() after a failed request = %+v, want %s stopped", result, requestID)
+	}
+}
+
+func TestBulkStopPlanCanNotBeTamperedWith(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	rankedID := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked"}, CurrentStatus: edgegaptest.StatusReady})
+	productionID := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"production"}, CurrentStatus: edgegaptest.StatusReady})
+
+	plan, err := client.PlanBulkStop(ctx, nil, edgegap.BulkStopGuardrails{AllowEmptyFilters: true, ProtectedTags: []string{"production"}})
+	if err != nil {
+		t.Fatalf("PlanBulkStop() error = %v", err)
+	}
+
+	violations := plan.Violations()
+	want := slices.Clone(violations)
+
+	clear(violations)
+	plan.Matches()[0].RequestID = productionID
+
+	var guardrailErr *edgegap.GuardrailError
+
+	if _, err := plan.Execute(ctx); !errors.As(err, &guardrailErr) || !slices.Equal(guardrailErr.Violations, want) || len(want) != 1 {
+		t.Errorf("Execute() error = %v, want the violations of the plan kept", err)
+	}
+
+	if ids := plan.IDs(); !slices.Equal(ids, []string{rankedID, productionID}) {
+		t.Errorf("IDs() = %v, want the matches of the plan kept", ids)
+	}
+}
+
+func TestSessionBulkStopPlan(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+	seedApp(server)
+
+	rankedID := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v1", Tags: []string{"ranked"}, CurrentStatus: edgegaptest.StatusReady})
+	casualID := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v1", Tags: []string{"casual"}, CurrentStatus: edgegaptest.StatusReady})
+
+	var sessions []string
+
+	for _, requestID := range []string{rankedID, rankedID, casualID} {
+		created, err := client.Sessions.Create(ctx, &edgegap.SessionCreate{App: "game", DeploymentRequestID: requestID})
+		if err != nil {
+			t.Fatalf("Sessions.Create() error = %v", err)
+		}
+
+		sessions = append(sessions, created.Data.SessionID)
+	}
+
+	if plan, err := client.PlanSessionBulkStop(ctx, ranked, edgegap.BulkStopGuardrails{ProtectedTags: []string{"ranked"}}); err != nil || len(plan.Violations()) != 2 {
+		t.Fatalf("PlanSessionBulkStop() with a protected tag = %+v, %v, want 2 violations", plan, err)
+	}
+
+	plan, err := client.PlanSessionBulkStop(ctx, ranked, edgegap.BulkStopGuardrails{RequiredTags: []string{"ranked"}})
+	if err != nil {
+		t.Fatalf("PlanSessionBulkStop() error = %v", err)
+	}
+
+	if ids := plan.IDs(); !slices.Equal(ids, sessions[:2]) || len(plan.Violations()) != 0 {
+		t.Fatalf("PlanSessionBulkStop() matched %v with violations %v, want %v", ids, plan.Violations(), sessions[:2])
+	}
+
+	result, err := plan.Execute(ctx, edgegap.BulkStopOptions{Wait: true, WaitOptions: fastPolls, Concurrency: 1})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !slices.Equal(result.Stopped, sessions[:2]) || len(result.Failed) != 0 {
+		t.Errorf("Execute() = %+v, want %v stopped", result, sessions[:2])
+	}
+
+	if _, err := client.Sessions.Get(ctx, sessions[2]); err != nil {
+		t.Errorf("session %s after Execute() error = %v, want it kept", sessions[2], err)
+	}
+}
+
+func TestBulkStopPlanGuardrails(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked"}})
+	server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: []string{"ranked", "production"}})
+
+	tests := []struct {
+		name       string
+		filters    []edgegap.Filter
+		guardrails edgegap.BulkStopGuardrails
+	}{
+		{"empty filters", nil, edgegap.BulkStopGuardrails{}},
+		{"max count", ranked, edgegap.BulkStopGuardrails{MaxCount: 1}},
+		{"required tag", ranked, edgegap.BulkStopGuardrails{RequiredTags: []string{"production"}}},
+		{"protected tag", ranked, edgegap.BulkStopGuardrails{ProtectedTags: []string{"production"}}},
+	}
+
+	for _, test := range tests {
+		plan, err := client.PlanBulkStop(ctx, test.filters, test.guardrails)
+		if err != nil {
+			t.Fatalf("%s : PlanBulkStop() error = %v", test.name, err)
+		}
+
+		var guardrailErr *edgegap.GuardrailError
+
+		if _, err := plan.Execute(ctx); !errors.As(err, &guardrailErr) || len(guardrailErr.Violations) != 1 {
+			t.Errorf("%s : Execute() error = %v, want a single violation", test.name, err)
+		}
+	}
+
+	for _, request := range server.Requests() {
+		if request.Path == "/deployments/bulk-stop" {
+			t.Fatalf("a bulk stop was sent despite the guardrails")
+		}
+	}
+
+	city := []edgegap.Filter{{Field: edgegap.ECity, FilterType: edgegap.EAny, Values: []string{"Montreal"}}}
+
+	var filterErr *edgegap.FilterError
+
+	if _, err := client.PlanBulkStop(ctx, city, edgegap.BulkStopGuardrails{}); !errors.As(err, &filterErr) {
+		t.Errorf("PlanBulkStop() on a city error = %v, want a FilterError", err)
+	}
+}
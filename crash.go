@@ -0,0 +1,289 @@
+package edgegap
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CrashKind classifies why a container exited.
+type CrashKind string
+
+const (
+	CrashOOMKilled = CrashKind("oom_killed") // Killed for using more memory than allowed
+	CrashSegfault  = CrashKind("segfault")   // Killed by a SIGSEGV, i.e. a segmentation fault
+	CrashSignal    = CrashKind("signal")     // Killed by another signal, see Crash.Signal
+	CrashExit      = CrashKind("exit")       // Exited with a non-zero code by itself
+	CrashImagePull = CrashKind("image_pull") // The image could not be pulled, the container never started
+	CrashUnknown   = CrashKind("unknown")    // Exited with code 0, or for a reason not recognized
+)
+
+// Default number of restarts from which a container is considered crash looping.
+const DefaultCrashLoopRestarts = 3
+
+// Default number of crash looping deployments from which a CrashTracker flags their app version.
+const DefaultVersionCrashLoops = 3
+
+// Messages reported when the image of a container could not be pulled.
+var imagePullMessages = []string{
+	"errimagepull",
+	"imagepullbackoff",
+	"pull access denied",
+	"manifest unknown",
+	"failed to pull",
+	"image not found",
+}
+
+// Crash is a classified crash of a container.
+type Crash struct {
+	Kind         CrashKind
+	ExitCode     int    // Exit code of the container
+	Signal       int    // Signal that killed the container, 0 when it exited by itself
+	Message      string // Message reported with the crash
+	RestartCount int    // Number of restarts of the container when it crashed
+}
+
+// CrashReport is the analysis of the crashes of a deployment.
+type CrashReport struct {
+	RequestID string  // Request ID of the deployment
+	Crashes   []Crash // The crashes, oldest first
+	Restarts  int     // Number of restarts of the container
+	CrashLoop bool    // If the container keeps crashing, see DefaultCrashLoopRestarts
+	CrashLogs string  // Logs of the crashed container
+}
+
+// Returns the last crash, or nil when the container did not crash.
+func (r *CrashReport) Last() *Crash {
+	if len(r.Crashes) == 0 {
+		return nil
+	}
+
+	return &r.Crashes[len(r.Crashes)-1]
+}
+
+// Describes the last crash in a sentence.
+func (r *CrashReport) String() string {
+	last := r.Last()
+	if last == nil {
+		return fmt.Sprintf("deployment %s did not crash", r.RequestID)
+	}
+
+	var cause string
+
+	switch last.Kind {
+	case CrashOOMKilled:
+		cause = "was killed for running out of memory"
+	case CrashSegfault:
+		cause = "crashed with a segmentation fault"
+	case CrashSignal:
+		cause = fmt.Sprintf("was killed by signal %d", last.Signal)
+	case CrashExit:
+		cause = fmt.Sprintf("exited with code %d", last.ExitCode)
+	case CrashImagePull:
+		cause = "could not pull its image"
+	default:
+		cause = fmt.Sprintf("stopped with code %d", last.ExitCode)
+	}
+
+	message := fmt.Sprintf("container of deployment %s %s", r.RequestID, cause)
+
+	if r.CrashLoop {
+		message += fmt.Sprintf(", crash looping after %d restarts", r.Restarts)
+	}
+
+	return message
+}
+
+// Analyzes the crash data of a deployment, as returned by Deployments.Logs.
+// The container is considered crash looping once restarted DefaultCrashLoopRestarts times.
+func AnalyzeCrash(requestID string, logs *DeploymentContainerLogs) *CrashReport {
+	report := &CrashReport{RequestID: requestID}
+
+	if logs == nil {
+		return report
+	}
+
+	report.CrashLogs = logs.CrashLogs
+
+	for _, data := range logs.CrashData {
+		report.Crashes = append(report.Crashes, ClassifyCrash(data))
+		report.Restarts = max(report.Restarts, data.RestartCount)
+	}
+
+	report.CrashLoop = report.Restarts >= DefaultCrashLoopRestarts
+
+	return report
+}
+
+// crashLoopDetector follows the restart count of a container across fetches of its logs, to detect a crash loop while
+// the deployment is still starting.
+type crashLoopDetector struct {
+	requestID string
+	restarts  int  // Restart count seen by the previous check
+	checked   bool // If a previous check was done
+}
+
+// check analyzes logs, reporting a crash loop once the restart count grew since the previous check and reached
+// DefaultCrashLoopRestarts. A container restarted long ago but now stable is not crash looping.
+func (d *crashLoopDetector) check(logs *DeploymentContainerLogs) *CrashReport {
+	report := AnalyzeCrash(d.requestID, logs)
+	grew := d.checked && report.Restarts > d.restarts
+
+	d.restarts, d.checked = report.Restarts, true
+	report.CrashLoop = grew && report.Restarts >= DefaultCrashLoopRestarts
+
+	return report
+}
+
+// Classifies a single crash of a container from its exit code and message.
+func ClassifyCrash(data ContainerCrashData) Crash {
+	crash := Crash{ExitCode: data.ExitCode, Message: data.Message, RestartCount: data.RestartCount}
+	message := strings.ToLower(data.Message)
+
+	if data.ExitCode > 128 && data.ExitCode < 160 {
+		crash.Signal = data.ExitCode - 128
+	}
+
+	switch {
+	case slices.ContainsFunc(imagePullMessages, func(pull string) bool { return strings.Contains(message, pull) }):
+		crash.Kind = CrashImagePull
+	case strings.Contains(message, "oomkilled") || strings.Contains(message, "out of memory") || (data.ExitCode == 137 && strings.Contains(message, "oom")):
+		crash.Kind = CrashOOMKilled
+	case data.ExitCode == 139 || strings.Contains(message, "sigsegv") || strings.Contains(message, "segmentation fault"):
+		crash.Kind = CrashSegfault
+		crash.Signal = 11
+	case crash.Signal != 0:
+		crash.Kind = CrashSignal
+	case data.ExitCode != 0:
+		crash.Kind = CrashExit
+	default:
+		crash.Kind = CrashUnknown
+	}
+
+	return crash
+}
+
+// FlaggedVersion is an app version whose deployments keep crash looping.
+type FlaggedVersion struct {
+	AppName    string
+	AppVersion string
+	Reports    []*CrashReport // Reports of the crash looping deployments, sorted by request ID
+}
+
+type appVersionKey struct {
+	app, version string
+}
+
+// CrashTracker gathers the crash reports of the deployments by app version, to flag a version crash looping across
+// many deployments. Given to a client with WithCrashTracker, it records the crashes met by WaitForDeployment and
+// WatchDeploymentStatus, and given to a DeploymentWatcher, the crashes of the deployments it sees failing.
+// It is safe for concurrent use.
+type CrashTracker struct {
+	threshold int
+	onFlag    func(version FlaggedVersion)
+
+	mu       sync.Mutex
+	versions map[appVersionKey]map[string]*CrashReport
+	flagged  map[appVersionKey]bool
+}
+
+// Creates a tracker flagging a version once threshold of its deployments crash looped, DefaultVersionCrashLoops when 0.
+// onFlag, when not nil, is called once for every flagged version.
+func NewCrashTracker(threshold int, onFlag func(version FlaggedVersion)) *CrashTracker {
+	if threshold <= 0 {
+		threshold = DefaultVersionCrashLoops
+	}
+
+	return &CrashTracker{
+		threshold: threshold,
+		onFlag:    onFlag,
+		versions:  map[appVersionKey]map[string]*CrashReport{},
+		flagged:   map[appVersionKey]bool{},
+	}
+}
+
+// Records the crash report of a deployment of an app version, reporting if the version is flagged.
+// Only crash looping deployments count towards the threshold.
+func (t *CrashTracker) Record(appName, appVersion string, report *CrashReport) bool {
+	key := appVersionKey{app: appName, version: appVersion}
+
+	t.mu.Lock()
+
+	if report.CrashLoop {
+		if t.versions[key] == nil {
+			t.versions[key] = map[string]*CrashReport{}
+		}
+
+		t.versions[key][report.RequestID] = report
+	}
+
+	newlyFlagged := !t.flagged[key] && len(t.versions[key]) >= t.threshold
+
+	if newlyFlagged {
+		t.flagged[key] = true
+	}
+
+	flagged := t.flagged[key]
+	version := t.version(key)
+
+	t.mu.Unlock()
+
+	if newlyFlagged && t.onFlag != nil {
+		t.onFlag(version)
+	}
+
+	return flagged
+}
+
+// record records the crash report of a deployment, when there is a tracker.
+func (t *CrashTracker) record(info *DeploymentInfo, report *CrashReport) {
+	if t != nil {
+		t.Record(info.AppName, info.AppVersion, report)
+	}
+}
+
+// Reports if an app version is flagged.
+func (t *CrashTracker) IsFlagged(appName, appVersion string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.flagged[appVersionKey{app: appName, version: appVersion}]
+}
+
+// Returns the flagged versions.
+func (t *CrashTracker) Flagged() []FlaggedVersion {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var versions []FlaggedVersion
+
+	for key := range t.flagged {
+		versions = append(versions, t.version(key))
+	}
+
+	slices.SortFunc(versions, func(a, b FlaggedVersion) int {
+		return strings.Compare(a.AppName+"/"+a.AppVersion, b.AppName+"/"+b.AppVersion)
+	})
+
+	return versions
+}
+
+// version returns the crash looping deployments of an app version. Must be called with mu held.
+func (t *CrashTracker) version(key appVersionKey) FlaggedVersion {
+	version := FlaggedVersion{AppName: key.app, AppVersion: key.version}
+
+	for _, id := range slices.Sorted(maps.Keys(t.versions[key])) {
+		version.Reports = append(version.Reports, t.versions[key][id])
+	}
+
+	return version
+}
+
+// Records the crashes of the deployments met by WaitForDeployment and WatchDeploymentStatus in tracker.
+func WithCrashTracker(tracker *CrashTracker) Option {
+	return func(o *clientOptions) {
+		o.crashes = tracker
+	}
+}
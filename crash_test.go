@@ -0,0 +1,96 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+func TestClassifyCrash(t *testing.T) {
+	tests := []struct {
+		data   edgegap.ContainerCrashData
+		kind   edgegap.CrashKind
+		signal int
+	}{
+		{edgegap.ContainerCrashData{ExitCode: 137, Message: "OOMKilled"}, edgegap.CrashOOMKilled, 9},
+		{edgegap.ContainerCrashData{ExitCode: 139}, edgegap.CrashSegfault, 11},
+		{edgegap.ContainerCrashData{ExitCode: 2, Message: "SIGSEGV: segmentation violation"}, edgegap.CrashSegfault, 11},
+		{edgegap.ContainerCrashData{ExitCode: 137}, edgegap.CrashSignal, 9},
+		{edgegap.ContainerCrashData{ExitCode: 1, Message: "panic: nil map"}, edgegap.CrashExit, 0},
+		{edgegap.ContainerCrashData{Message: "Back-off pulling image: ImagePullBackOff"}, edgegap.CrashImagePull, 0},
+		{edgegap.ContainerCrashData{}, edgegap.CrashUnknown, 0},
+	}
+
+	for _, test := range tests {
+		crash := edgegap.ClassifyCrash(test.data)
+
+		if crash.Kind != test.kind || crash.Signal != test.signal {
+			t.Errorf("ClassifyCrash(%+v) = %s, signal %d, want %s, signal %d", test.data, crash.Kind, crash.Signal, test.kind, test.signal)
+		}
+	}
+}
+
+func TestAnalyzeCrash(t *testing.T) {
+	report := edgegap.AnalyzeCrash("9f511e17dfa4", &edgegap.DeploymentContainerLogs{
+		CrashLogs: "fatal error: out of memory",
+		CrashData: []edgegap.ContainerCrashData{
+			{ExitCode: 1, RestartCount: 1},
+			{ExitCode: 137, Message: "OOMKilled", RestartCount: 3},
+		},
+	})
+
+	if !report.CrashLoop || report.Restarts != 3 || len(report.Crashes) != 2 {
+		t.Fatalf("AnalyzeCrash() = %+v, want a crash loop after 3 restarts", report)
+	}
+
+	if report.Last().Kind != edgegap.CrashOOMKilled || !strings.Contains(report.String(), "out of memory") {
+		t.Errorf("AnalyzeCrash() last crash = %+v, String() = %q", report.Last(), report.String())
+	}
+
+	if report := edgegap.AnalyzeCrash("9f511e17dfa4", nil); report.CrashLoop || report.Last() != nil {
+		t.Errorf("AnalyzeCrash(nil) = %+v, want no crash", report)
+	}
+}
+
+func TestCrashTrackerFlagsVersion(t *testing.T) {
+	var flagged []edgegap.FlaggedVersion
+
+	tracker := edgegap.NewCrashTracker(2, func(version edgegap.FlaggedVersion) {
+		flagged = append(flagged, version)
+	})
+
+	server, client := newTestClient(t, edgegap.WithCrashTracker(tracker))
+
+	crashLoop := edgegap.DeploymentContainerLogs{
+		CrashData: []edgegap.ContainerCrashData{{ExitCode: 139, RestartCount: 5}},
+	}
+
+	for i := 0; i < 3; i++ {
+		id := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v2", CurrentStatus: edgegaptest.StatusError})
+		server.SetContainerLogs(id, crashLoop)
+
+		_, err := client.WaitForDeployment(context.Background(), id, fastPolls)
+
+		var deploymentErr *edgegap.DeploymentError
+
+		if !errors.As(err, &deploymentErr) || deploymentErr.Report == nil || !deploymentErr.Report.CrashLoop {
+			t.Fatalf("WaitForDeployment() error = %v, want a crash looping DeploymentError", err)
+		}
+	}
+
+	if !tracker.IsFlagged("game", "v2") || tracker.IsFlagged("game", "v1") {
+		t.Errorf("IsFlagged() = %v, want only game v2 flagged", tracker.Flagged())
+	}
+
+	if len(flagged) != 1 || len(flagged[0].Reports) != 2 {
+		t.Errorf("onFlag called with %+v, want game v2 once, with 2 reports", flagged)
+	}
+
+	if versions := tracker.Flagged(); len(versions) != 1 || len(versions[0].Reports) != 3 {
+		t.Errorf("Flagged() = %+v, want game v2 with 3 reports", versions)
+	}
+}
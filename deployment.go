@@ -5,9 +5,10 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"iter"
+	"net/http"
 )
 
 const DEPLOYMENT_ENDPOINT = "/deploy"
@@ -26,6 +27,18 @@ type DeploymentAvailableSocketPayload struct {
 	Longitude      string   `json:"longitude,omitempty"`       // The longitude of the deployment
 }
 
+// Statuses reported by DeploymentInfo.CurrentStatus.
+const (
+	DeploymentStatusInitializing = "Status.INITIALIZING"
+	DeploymentStatusSeeking      = "Status.SEEKING"
+	DeploymentStatusSeeked       = "Status.SEEKED"
+	DeploymentStatusScanning     = "Status.SCANNING"
+	DeploymentStatusDeploying    = "Status.DEPLOYING"
+	DeploymentStatusReady        = "Status.READY"
+	DeploymentStatusError        = "Status.ERROR"
+	DeploymentStatusTerminated   = "Status.TERMINATED"
+)
+
 type DeploymentInfo struct {
 	RequestID          string                 `json:"request_id"`          // The Unique ID of the Deployment's request
 	FDQN               string                 `json:"fdqn"`                // The FQDN that allow to connect to your Deployment
@@ -110,70 +123,174 @@ type DeploymentBulkDelete struct {
 	RequestIDs []string `json:"processable,omitempty"`
 }
 
+type DeploymentStopResponse struct {
+	Message           string         `json:"message"`            // Message of the API about the stop request
+	DeploymentSummary DeploymentInfo `json:"deployment_summary"` // State of the deployment when the stop request was accepted
+}
+
+// Reports if the deployment was still running when the stop request was accepted. Stopping is asynchronous :
+// the deployment keeps running until its status reaches DeploymentStatusTerminated, see EdgegapClient.WaitForStopped.
+func (r *DeploymentStopResponse) Pending() bool {
+	return r.DeploymentSummary.CurrentStatus != DeploymentStatusTerminated
+}
+
+// DeploymentsService manages the deployments, see EdgegapClient.Deployments.
+type DeploymentsService struct {
+	client *EdgegapClient
+}
+
 // Create a new deployment. Deployment is a server instance of your application version.
-func (e *EdgegapClient) DeploymentCreate(data *DeployementCreatePayload) (*Response[DeploymentCreateResponse], error) {
+func (s *DeploymentsService) Create(ctx context.Context, data *DeployementCreatePayload) (*Response[DeploymentCreateResponse], error) {
 	var successResponse DeploymentCreateResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(data).Post(DEPLOYMENT_ENDPOINT)
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentCreate",
+		method:    http.MethodPost,
+		path:      DEPLOYMENT_ENDPOINT,
+		body:      data,
 	}, &successResponse)
 }
 
 // Retrieve the logs of your container. Logs are not available when your deployment is terminated
-func (e *EdgegapClient) DeploymentContainerLogs(requestId string) (*Response[DeploymentContainerLogs], error) {
+func (s *DeploymentsService) Logs(ctx context.Context, requestId string) (*Response[DeploymentContainerLogs], error) {
 	endpoint := fmt.Sprintf("%s/%s/container-logs", DEPLOYMENT_ENDPOINT, requestId)
 	var containerLogs DeploymentContainerLogs
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(endpoint)
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentContainerLogs",
+		method:    http.MethodGet,
+		path:      endpoint,
+		params:    map[string]string{paramRequestID: requestId},
 	}, &containerLogs)
 }
 
-// List all deployments.
-func (e *EdgegapClient) DeploymentListAll() (*Response[ResponseBody[Deployment]], error) {
+// List all deployments. Only the first page is returned, use ListPage or Iterate to get the others.
+func (s *DeploymentsService) List(ctx context.Context) (*Response[ResponseBody[Deployment]], error) {
 	var deploymentList ResponseBody[Deployment]
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/deployments")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentListAll",
+		method:    http.MethodGet,
+		path:      "/deployments",
 	}, &deploymentList)
 }
 
+// List a single page of deployments.
+func (s *DeploymentsService) ListPage(ctx context.Context, params PaginationParams) (*Response[ResponseBody[Deployment]], error) {
+	var deploymentList ResponseBody[Deployment]
+
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentListPage",
+		method:    http.MethodGet,
+		path:      "/deployments" + params.GetParams(),
+	}, &deploymentList)
+}
+
+// Iterate over every deployment, fetching the pages as they are needed.
+func (s *DeploymentsService) Iterate(ctx context.Context) iter.Seq2[Deployment, error] {
+	return paginate(ctx, func(ctx context.Context, params PaginationParams) ([]Deployment, Pagination, error) {
+		res, err := s.ListPage(ctx, params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		return res.Data.Data, res.Data.Pagination, nil
+	})
+}
+
 // Make a bulk delete of deployments using filters. All the deployments matching the given filters will be permanently deleted.
-func (e *EdgegapClient) DeploymentBulkDelete(filters []Filter) (*Response[DeploymentBulkDelete], error) {
+func (s *DeploymentsService) BulkDelete(ctx context.Context, filters []Filter) (*Response[DeploymentBulkDelete], error) {
 	var bulkDeleteResponse DeploymentBulkDelete
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string]interface{}{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentBulkDelete",
+		method:    http.MethodPost,
+		path:      "/deployments/bulk-stop",
+		body: map[string]interface{}{
 			"filters": filters,
-		}).Post("/deployments/bulk-stiop")
+		},
 	}, &bulkDeleteResponse)
 }
 
+// Stop a deployment. The stop request is queued : the deployment is terminated asynchronously, use EdgegapClient.WaitForStopped to wait for it.
+func (s *DeploymentsService) Stop(ctx context.Context, requestId string) (*Response[DeploymentStopResponse], error) {
+	var response DeploymentStopResponse
+
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentStop",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/stop/%s", requestId),
+		params:    map[string]string{paramRequestID: requestId},
+	}, &response)
+}
+
+// Stop a deployment, like Deployments.Stop. The stop request is queued : the deployment is terminated asynchronously,
+// use EdgegapClient.WaitForStopped to wait for it.
+func (e *EdgegapClient) DeploymentStop(ctx context.Context, requestId string) (*Response[DeploymentStopResponse], error) {
+	return e.Deployments.Stop(ctx, requestId)
+}
+
+// Stop the deployment running this code, from inside its container, with the URL and token Edgegap injects in the
+// ARBITRIUM_DELETE_URL and ARBITRIUM_DELETE_TOKEN environment variables. The token of the client is not sent.
+func (s *DeploymentsService) SelfStop(ctx context.Context, deleteURL string, deleteToken string) (*Response[DeploymentStopResponse], error) {
+	var response DeploymentStopResponse
+
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentSelfStop",
+		method:    http.MethodDelete,
+		path:      deleteURL,
+		header:    http.Header{"Authorization": []string{deleteToken}},
+	}, &response)
+}
+
+// Get the deployment running this code, from inside its container, with the URL and token Edgegap injects in the
+// ARBITRIUM_CONTEXT_URL and ARBITRIUM_CONTEXT_TOKEN environment variables. The token of the client is not sent.
+func (s *DeploymentsService) SelfContext(ctx context.Context, contextURL string, contextToken string) (*Response[DeploymentInfo], error) {
+	var info DeploymentInfo
+
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentSelfContext",
+		method:    http.MethodGet,
+		path:      contextURL,
+		header:    http.Header{"Authorization": []string{contextToken}},
+	}, &info)
+}
+
 // Updates properties of a deployment. Currently only the is_joinable_by_session property can be updated.
-func (e *EdgegapClient) DeploymentPropertyUpdate(requestId string, isJoinableSession bool) (*Response[DeploymentUpdateResponse], error) {
+func (s *DeploymentsService) Update(ctx context.Context, requestId string, isJoinableSession bool) (*Response[DeploymentUpdateResponse], error) {
 	var response DeploymentUpdateResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string]interface{}{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentPropertyUpdate",
+		method:    http.MethodPost,
+		path:      fmt.Sprintf("/deployments/%s", requestId),
+		params:    map[string]string{paramRequestID: requestId},
+		body: map[string]interface{}{
 			"is_joinable_by_session": isJoinableSession,
-		}).Post(fmt.Sprintf("/deployments/%s", requestId))
+		},
 	}, &response)
 }
 
 // Get the list of deployments that have available sockets sorted by proximity to the geographical data.
-func (e *EdgegapClient) DeploymentWithAvailableSockets(data DeploymentAvailableSocketPayload) (*Response[ResponseBody[Deployment]], error) {
+func (s *DeploymentsService) Available(ctx context.Context, data DeploymentAvailableSocketPayload) (*Response[ResponseBody[Deployment]], error) {
 	var deploymentList ResponseBody[Deployment]
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(data).Post("/deployments:available")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentWithAvailableSockets",
+		method:    http.MethodPost,
+		path:      "/deployments:available",
+		body:      data,
 	}, &deploymentList)
 }
 
 // Retrieve the information for a deployment.
-func (e *EdgegapClient) DeploymentGetStatus(request_id string) (*Response[DeploymentInfo], error) {
+func (s *DeploymentsService) Get(ctx context.Context, request_id string) (*Response[DeploymentInfo], error) {
 	var deploymentInfo DeploymentInfo
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/status/%s", request_id))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "DeploymentGetStatus",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/status/%s", request_id),
+		params:    map[string]string{paramRequestID: request_id},
 	}, &deploymentInfo)
 }
@@ -0,0 +1,212 @@
+package edgegap_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+func TestDeploymentCreateAndStatus(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	created, err := client.DeploymentCreate(ctx, &edgegap.DeployementCreatePayload{
+		AppName:     "game",
+		VersionName: "v1",
+		IpList:      []string{"198.51.100.1"},
+		Tags:        []string{"ranked"},
+	})
+	if err != nil {
+		t.Fatalf("DeploymentCreate() error = %v", err)
+	}
+
+	id := created.Data.RequestID
+
+	want := []string{edgegaptest.StatusSeeking, edgegaptest.StatusDeploying, edgegaptest.StatusReady}
+
+	for _, status := range want {
+		res, err := client.DeploymentGetStatus(ctx, id)
+		if err != nil {
+			t.Fatalf("DeploymentGetStatus() error = %v", err)
+		}
+
+		if res.Data.CurrentStatus != status {
+			t.Errorf("CurrentStatus = %q, want %q", res.Data.CurrentStatus, status)
+		}
+	}
+
+	info, _ := server.Deployment(id)
+
+	if !info.Running || info.Ports["gameport"].Internal != 7777 {
+		t.Errorf("Deployment() = %+v, want a running deployment exposing the game port", info)
+	}
+}
+
+func TestDeploymentCreateUnknownApp(t *testing.T) {
+	_, client := newTestClient(t)
+
+	_, err := client.DeploymentCreate(context.Background(), &edgegap.DeployementCreatePayload{AppName: "missing"})
+
+	if !edgegap.IsNotFound(err) {
+		t.Fatalf("DeploymentCreate() error = %v, want not found", err)
+	}
+}
+
+func TestDeploymentContainerLogs(t *testing.T) {
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	server.SetContainerLogs(id, edgegap.DeploymentContainerLogs{Logs: "server started\n"})
+
+	res, err := client.DeploymentContainerLogs(context.Background(), id)
+	if err != nil {
+		t.Fatalf("DeploymentContainerLogs() error = %v", err)
+	}
+
+	if res.Data.Logs != "server started\n" {
+		t.Errorf("Logs = %q, want %q", res.Data.Logs, "server started\n")
+	}
+}
+
+func TestDeploymentWithAvailableSockets(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, edgegap.WithRetryPolicy(edgegap.RetryPolicy{}))
+	seedApp(server)
+
+	created, err := client.DeploymentCreate(ctx, &edgegap.DeployementCreatePayload{AppName: "game", VersionName: "v1"})
+	if err != nil {
+		t.Fatalf("DeploymentCreate() error = %v", err)
+	}
+
+	server.SetDeploymentStatus(created.Data.RequestID, edgegaptest.StatusReady)
+
+	res, err := client.DeploymentWithAvailableSockets(ctx, edgegap.DeploymentAvailableSocketPayload{AppName: "game", AppVersion: "v1", MinimumSockets: 2})
+	if err != nil {
+		t.Fatalf("DeploymentWithAvailableSockets() error = %v", err)
+	}
+
+	if len(res.Data.Data) != 1 {
+		t.Fatalf("DeploymentWithAvailableSockets() = %d deployments, want 1", len(res.Data.Data))
+	}
+
+	if _, err := client.DeploymentPropertyUpdate(ctx, created.Data.RequestID, false); err != nil {
+		t.Fatalf("DeploymentPropertyUpdate() error = %v", err)
+	}
+
+	res, err = client.DeploymentWithAvailableSockets(ctx, edgegap.DeploymentAvailableSocketPayload{AppName: "game", AppVersion: "v1"})
+	if err != nil {
+		t.Fatalf("DeploymentWithAvailableSockets() error = %v", err)
+	}
+
+	if len(res.Data.Data) != 0 {
+		t.Errorf("DeploymentWithAvailableSockets() = %d deployments, want none once not joinable", len(res.Data.Data))
+	}
+}
+
+func TestDeploymentBulkDelete(t *testing.T) {
+	server, client := newTestClient(t)
+
+	ranked := server.AddDeployment(edgegap.DeploymentInfo{Tags: []string{"ranked"}})
+	casual := server.AddDeployment(edgegap.DeploymentInfo{Tags: []string{"casual"}})
+
+	res, err := client.DeploymentBulkDelete(context.Background(), []edgegap.Filter{
+		{Field: edgegap.EDeploymentTag, Values: []string{"ranked"}, FilterType: edgegap.EAny},
+	})
+	if err != nil {
+		t.Fatalf("DeploymentBulkDelete() error = %v", err)
+	}
+
+	if len(res.Data.RequestIDs) != 1 || res.Data.RequestIDs[0] != ranked {
+		t.Errorf("RequestIDs = %v, want [%s]", res.Data.RequestIDs, ranked)
+	}
+
+	if info, _ := server.Deployment(casual); info.CurrentStatus == edgegaptest.StatusTerminated {
+		t.Errorf("deployment %s was stopped, want it kept", casual)
+	}
+}
+
+func TestDeploymentStop(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	res, err := client.DeploymentStop(ctx, id)
+	if err != nil {
+		t.Fatalf("DeploymentStop() error = %v", err)
+	}
+
+	if !res.Data.Pending() {
+		t.Errorf("Pending() = false, want the stop to be queued")
+	}
+
+	info, err := client.WaitForStopped(ctx, id, fastPolls)
+	if err != nil {
+		t.Fatalf("WaitForStopped() error = %v", err)
+	}
+
+	if info.CurrentStatus != edgegap.DeploymentStatusTerminated {
+		t.Errorf("CurrentStatus = %q, want %q", info.CurrentStatus, edgegap.DeploymentStatusTerminated)
+	}
+
+	if _, err := client.Deployments.Stop(ctx, id); !edgegap.IsNotFound(err) {
+		t.Errorf("Deployments.Stop() of a terminated deployment error = %v, want not found", err)
+	}
+}
+
+func TestWaitForStoppedUnknownDeployment(t *testing.T) {
+	_, client := newTestClient(t)
+
+	if info, err := client.WaitForStopped(context.Background(), "missing", fastPolls); err != nil || info != nil {
+		t.Errorf("WaitForStopped() = %v, %v, want a deployment already gone", info, err)
+	}
+}
+
+func TestWaitForStoppedDeploymentGone(t *testing.T) {
+	server, _ := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+	server.SetDeploymentStatus(id, edgegaptest.StatusDeploying)
+
+	var gone sync.Once
+
+	// The deployment is seen once, then the API does not know it anymore.
+	client := server.Client(edgegap.WithMiddleware(func(next edgegap.RoundTrip) edgegap.RoundTrip {
+		return func(ctx context.Context, call *edgegap.Call) (*edgegap.Result, error) {
+			result, err := next(ctx, call)
+			gone.Do(func() { server.InjectFault(edgegaptest.Fault{Path: "/status/", Status: http.StatusNotFound}) })
+
+			return result, err
+		}
+	}))
+
+	if info, err := client.WaitForStopped(context.Background(), id, fastPolls); err != nil || info != nil {
+		t.Errorf("WaitForStopped() = %v, %v, want a deployment gone while waiting", info, err)
+	}
+}
+
+func TestDeploymentSelfStop(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	deleteURL, deleteToken, _ := server.SelfStopCredentials(id)
+
+	// A game server only knows the delete URL and token, not the API token.
+	client := edgegap.NewEdgegapClient("")
+
+	if _, err := client.Deployments.SelfStop(ctx, deleteURL, "wrong"); !edgegap.IsUnauthorized(err) {
+		t.Errorf("Deployments.SelfStop() with a wrong token error = %v, want unauthorized", err)
+	}
+
+	if _, err := client.Deployments.SelfStop(ctx, deleteURL, deleteToken); err != nil {
+		t.Fatalf("Deployments.SelfStop() error = %v", err)
+	}
+
+	if _, err := server.Client().WaitForStopped(ctx, id, fastPolls); err != nil {
+		t.Errorf("WaitForStopped() error = %v", err)
+	}
+}
@@ -0,0 +1,555 @@
+package edgegap
+
+import (
+	"context"
+	"iter"
+)
+
+// Create an application that will regroup application versions.
+//
+// Deprecated: use EdgegapClient.Applications.Create instead.
+func (e *EdgegapClient) ApplicationCreate(ctx context.Context, application ApplicationCreate) (*Response[Application], error) {
+	return e.Applications.Create(ctx, application)
+}
+
+// Update an application with new information.
+//
+// Deprecated: use EdgegapClient.Applications.Update instead.
+func (e *EdgegapClient) ApplicationUpdate(ctx context.Context, name string, application ApplicationCreate) (*Response[Application], error) {
+	return e.Applications.Update(ctx, name, application)
+}
+
+// Delete an application and all its current versions.
+//
+// Deprecated: use EdgegapClient.Applications.Delete instead.
+func (e *EdgegapClient) ApplicationDelete(ctx context.Context, name string) (*Response[map[string]interface{}], error) {
+	return e.Applications.Delete(ctx, name)
+}
+
+// Retrieve an application and its information.
+//
+// Deprecated: use EdgegapClient.Applications.Get instead.
+func (e *EdgegapClient) Application(ctx context.Context, name string) (*Response[Application], error) {
+	return e.Applications.Get(ctx, name)
+}
+
+// Create an application version associated with an application. The version contains all the specifications to create a deployment.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.Create instead.
+func (e *EdgegapClient) ApplicationCreateVersion(ctx context.Context, appName string, version ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error) {
+	return e.Applications.Versions.Create(ctx, appName, version)
+}
+
+// Delete a specific version of an application.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.Delete instead.
+func (e *EdgegapClient) ApplicationDeleteVersion(ctx context.Context, appName string, version string) (*Response[map[string]interface{}], error) {
+	return e.Applications.Versions.Delete(ctx, appName, version)
+}
+
+// Retrieve the specifications of an application version.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.Get instead.
+func (e *EdgegapClient) ApplicationGetVersion(ctx context.Context, appName string, version string) (*Response[ApplicationVersion], error) {
+	return e.Applications.Versions.Get(ctx, appName, version)
+}
+
+// Update an application version with new specifications.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.Update instead.
+func (e *EdgegapClient) ApplicationUpdateVersion(ctx context.Context, appName string, version string, data ApplicationVersion) (*Response[ApplicationVersionCreateResponse], error) {
+	return e.Applications.Versions.Update(ctx, appName, version, data)
+}
+
+// Create an access control list entry for an app version. This will allow the specified CIDR to connect to the deployment. The option whitelisting_active must be activated in the application version.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.ACL.Create instead.
+func (e *EdgegapClient) ApplicationCreateACLEntry(ctx context.Context, appName string, version string, data ApplicationACL) (*Response[ApplicationACLCreateResponse], error) {
+	return e.Applications.Versions.ACL.Create(ctx, appName, version, data)
+}
+
+// List all the access control list entries for a specific application version.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.ACL.List instead.
+func (e *EdgegapClient) ApplicationACLEntries(ctx context.Context, appName string, version string) (*Response[ApplicationACLEntries], error) {
+	return e.Applications.Versions.ACL.List(ctx, appName, version)
+}
+
+// Delete an access control list entry for a specific application version
+//
+// Deprecated: use EdgegapClient.Applications.Versions.ACL.Delete instead.
+func (e *EdgegapClient) ApplicationDeleteACL(ctx context.Context, appName string, version string, entryId string) (*Response[ApplicationACLCreateResponse], error) {
+	return e.Applications.Versions.ACL.Delete(ctx, appName, version, entryId)
+}
+
+// Retrieve a specific access control list entry for an application version.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.ACL.Get instead.
+func (e *EdgegapClient) ApplicationGetACLById(ctx context.Context, appName string, version string, entryId string) (*Response[ApplicationACL], error) {
+	return e.Applications.Versions.ACL.Get(ctx, appName, version, entryId)
+}
+
+// List all versions of a specific application.
+//
+// Deprecated: use EdgegapClient.Applications.Versions.List instead.
+func (e *EdgegapClient) ApplicationListVersion(ctx context.Context, appName string) (*Response[ApplicationVersionList], error) {
+	return e.Applications.Versions.List(ctx, appName)
+}
+
+// List all the applications that you own.
+//
+// Deprecated: use EdgegapClient.Applications.List instead.
+func (e *EdgegapClient) ApplicationGetList(ctx context.Context) (*Response[ApplicationList], error) {
+	return e.Applications.List(ctx)
+}
+
+// Create a new deployment. Deployment is a server instance of your application version.
+//
+// Deprecated: use EdgegapClient.Deployments.Create instead.
+func (e *EdgegapClient) DeploymentCreate(ctx context.Context, data *DeployementCreatePayload) (*Response[DeploymentCreateResponse], error) {
+	return e.Deployments.Create(ctx, data)
+}
+
+// Retrieve the logs of your container. Logs are not available when your deployment is terminated
+//
+// Deprecated: use EdgegapClient.Deployments.Logs instead.
+func (e *EdgegapClient) DeploymentContainerLogs(ctx context.Context, requestId string) (*Response[DeploymentContainerLogs], error) {
+	return e.Deployments.Logs(ctx, requestId)
+}
+
+// List all deployments. Only the first page is returned, use DeploymentListPage or DeploymentIterate to get the others.
+//
+// Deprecated: use EdgegapClient.Deployments.List instead.
+func (e *EdgegapClient) DeploymentListAll(ctx context.Context) (*Response[ResponseBody[Deployment]], error) {
+	return e.Deployments.List(ctx)
+}
+
+// List a single page of deployments.
+//
+// Deprecated: use EdgegapClient.Deployments.ListPage instead.
+func (e *EdgegapClient) DeploymentListPage(ctx context.Context, params PaginationParams) (*Response[ResponseBody[Deployment]], error) {
+	return e.Deployments.ListPage(ctx, params)
+}
+
+// Iterate over every deployment, fetching the pages as they are needed.
+//
+// Deprecated: use EdgegapClient.Deployments.Iterate instead.
+func (e *EdgegapClient) DeploymentIterate(ctx context.Context) iter.Seq2[Deployment, error] {
+	return e.Deployments.Iterate(ctx)
+}
+
+// Make a bulk delete of deployments using filters. All the deployments matching the given filters will be permanently deleted.
+//
+// Deprecated: use EdgegapClient.Deployments.BulkDelete instead.
+func (e *EdgegapClient) DeploymentBulkDelete(ctx context.Context, filters []Filter) (*Response[DeploymentBulkDelete], error) {
+	return e.Deployments.BulkDelete(ctx, filters)
+}
+
+// Updates properties of a deployment. Currently only the is_joinable_by_session property can be updated.
+//
+// Deprecated: use EdgegapClient.Deployments.Update instead.
+func (e *EdgegapClient) DeploymentPropertyUpdate(ctx context.Context, requestId string, isJoinableSession bool) (*Response[DeploymentUpdateResponse], error) {
+	return e.Deployments.Update(ctx, requestId, isJoinableSession)
+}
+
+// Get the list of deployments that have available sockets sorted by proximity to the geographical data.
+//
+// Deprecated: use EdgegapClient.Deployments.Available instead.
+func (e *EdgegapClient) DeploymentWithAvailableSockets(ctx context.Context, data DeploymentAvailableSocketPayload) (*Response[ResponseBody[Deployment]], error) {
+	return e.Deployments.Available(ctx, data)
+}
+
+// Retrieve the information for a deployment.
+//
+// Deprecated: use EdgegapClient.Deployments.Get instead.
+func (e *EdgegapClient) DeploymentGetStatus(ctx context.Context, request_id string) (*Response[DeploymentInfo], error) {
+	return e.Deployments.Get(ctx, request_id)
+}
+
+// Create a fleet. A fleet is a top-level object; you must create child resources to work properly.
+//
+// Deprecated: use EdgegapClient.Fleets.Create instead.
+func (e *EdgegapClient) FleetCreate(ctx context.Context, payload FleetCreatePayload) (*Response[Fleet], error) {
+	return e.Fleets.Create(ctx, payload)
+}
+
+// Retrieve a fleet with its details.
+//
+// Deprecated: use EdgegapClient.Fleets.Get instead.
+func (e *EdgegapClient) FleetGet(ctx context.Context, name string) (*Response[Fleet], error) {
+	return e.Fleets.Get(ctx, name)
+}
+
+// Update a fleet with new specifications
+//
+// Deprecated: use EdgegapClient.Fleets.Update instead.
+func (e *EdgegapClient) FleetUpdate(ctx context.Context, name string, payload FleetCreatePayload) (*Response[Fleet], error) {
+	return e.Fleets.Update(ctx, name, payload)
+}
+
+// Delete a fleet, its policies and links between the application versions.
+//
+// Deprecated: use EdgegapClient.Fleets.Delete instead.
+func (e *EdgegapClient) FleetDelete(ctx context.Context, name string) (*Response[interface{}], error) {
+	return e.Fleets.Delete(ctx, name)
+}
+
+// List all the fleets you own. Only the first page is returned, use FleetListPage or FleetIterate to get the others.
+//
+// Deprecated: use EdgegapClient.Fleets.List instead.
+func (e *EdgegapClient) FleetList(ctx context.Context) (*Response[FleetList], error) {
+	return e.Fleets.List(ctx)
+}
+
+// List a single page of the fleets you own.
+//
+// Deprecated: use EdgegapClient.Fleets.ListPage instead.
+func (e *EdgegapClient) FleetListPage(ctx context.Context, params PaginationParams) (*Response[FleetList], error) {
+	return e.Fleets.ListPage(ctx, params)
+}
+
+// Iterate over every fleet you own, fetching the pages as they are needed.
+//
+// Deprecated: use EdgegapClient.Fleets.Iterate instead.
+func (e *EdgegapClient) FleetIterate(ctx context.Context) iter.Seq2[Fleet, error] {
+	return e.Fleets.Iterate(ctx)
+}
+
+// Link an application version to a fleet. By linking this version, the fleet will automatically create deployments of this version according to the fleet policies.
+//
+// Deprecated: use EdgegapClient.Fleets.Link instead.
+func (e *EdgegapClient) FleetLinkApplication(ctx context.Context, fleet, app, version string) (*Response[FleetApplication], error) {
+	return e.Fleets.Link(ctx, fleet, app, version)
+}
+
+// Unlink an application version from a fleet. It will not delete the application version or the fleet
+//
+// Deprecated: use EdgegapClient.Fleets.Unlink instead.
+func (e *EdgegapClient) FleetUnlinkApplication(ctx context.Context, fleet, app, version string) (*Response[interface{}], error) {
+	return e.Fleets.Unlink(ctx, fleet, app, version)
+}
+
+// Retrieve your public IP address.
+//
+// Deprecated: use EdgegapClient.IP.Get instead.
+func (e *EdgegapClient) IPGet(ctx context.Context) (*Response[PublicIPResponse], error) {
+	return e.IP.Get(ctx)
+}
+
+// Lookup an IP address and return the associated information.
+//
+// Deprecated: use EdgegapClient.IP.Lookup instead.
+func (e *EdgegapClient) IPGetInfo(ctx context.Context, ip string) (*Response[IPInformation], error) {
+	return e.IP.Lookup(ctx, ip)
+}
+
+// Lookup IP addresses and return the associated information. Maximum of 20 IPs.
+//
+// Deprecated: use EdgegapClient.IP.LookupBulk instead.
+func (e *EdgegapClient) IPGetInfoBulk(ctx context.Context, payload IPBulkInfoPayload) (*Response[IPBulkInfo], error) {
+	return e.IP.LookupBulk(ctx, payload)
+}
+
+// List all the locations available to deploy on. You can specify an application and a version to filter out the locations that don’t have enough resources to deploy this application version.
+//
+// Deprecated: use EdgegapClient.Locations.List instead.
+func (e *EdgegapClient) LocationListAll(ctx context.Context, filters LocationFilters) (*Response[LocationListRes], error) {
+	return e.Locations.List(ctx, filters)
+}
+
+// List all the active location beacons. They can be used to ping them for your matchmaking system. You cannot deploy on beacons.
+//
+// Deprecated: use EdgegapClient.Locations.Beacons instead.
+func (e *EdgegapClient) LocationListAllBeacons(ctx context.Context) (*Response[LocationBeaconRes], error) {
+	return e.Locations.Beacons(ctx)
+}
+
+// Create a new matchmaker component.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Create instead.
+func (e *EdgegapClient) MatchmakerCreateComponent(ctx context.Context, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error) {
+	return e.Matchmaker.Components.Create(ctx, component)
+}
+
+// Update a matchmaker component with new specifications.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Update instead.
+func (e *EdgegapClient) MatchmakerUpdateComponent(ctx context.Context, name string, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error) {
+	return e.Matchmaker.Components.Update(ctx, name, component)
+}
+
+// Delete a matchmaker component. It will not delete the matchmaker.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Delete instead.
+func (e *EdgegapClient) MatchmakerDeleteComponent(ctx context.Context, name string) (*Response[map[string]string], error) {
+	return e.Matchmaker.Components.Delete(ctx, name)
+}
+
+// Retrieve a matchmaker component.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Get instead.
+func (e *EdgegapClient) MatchmakerGetComponent(ctx context.Context, name string) (*Response[MatchmakerComponent], error) {
+	return e.Matchmaker.Components.Get(ctx, name)
+}
+
+// Create a new matchmaker component ENV.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Envs.Create instead.
+func (e *EdgegapClient) MatchmakerComponentAddEnv(ctx context.Context, name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error) {
+	return e.Matchmaker.Components.Envs.Create(ctx, name, env)
+}
+
+// Update a matchmaker component ENV.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Envs.Update instead.
+func (e *EdgegapClient) MatchmakerComponentUpdateEnv(ctx context.Context, name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error) {
+	return e.Matchmaker.Components.Envs.Update(ctx, name, env)
+}
+
+// Delete a matchmaker component ENV. It will not delete the component or the matchmaker.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Envs.Delete instead.
+func (e *EdgegapClient) MatchmakerComponentDeleteEnv(ctx context.Context, name string, env string) (*Response[map[string]string], error) {
+	return e.Matchmaker.Components.Envs.Delete(ctx, name, env)
+}
+
+// Retrieve a matchmaker component ENV.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Envs.Get instead.
+func (e *EdgegapClient) MatchmakeComponentGetEnv(ctx context.Context, name string, env string) (*Response[MatchmakerEnvRes], error) {
+	return e.Matchmaker.Components.Envs.Get(ctx, name, env)
+}
+
+// List all ENVs for a specific matchmaker component.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Components.Envs.List instead.
+func (e *EdgegapClient) MatchmakerComponentListEnv(ctx context.Context, name string) (*Response[MatchmakerEnvListRes], error) {
+	return e.Matchmaker.Components.Envs.List(ctx, name)
+}
+
+// Deprecated: use EdgegapClient.Matchmaker.Components.List instead.
+func (e *EdgegapClient) MatchmakerComponentList(ctx context.Context) (*Response[MatchmakerComponentListRes], error) {
+	return e.Matchmaker.Components.List(ctx)
+}
+
+// Create a new matchmaker. A matchmaker is a top-level object; you must create child resources to work properly.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Create instead.
+func (e *EdgegapClient) MatchmakerCreate(ctx context.Context, name string) (*Response[Matchmaker], error) {
+	return e.Matchmaker.Create(ctx, name)
+}
+
+// Update a matchmaker with new specifications.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Update instead.
+func (e *EdgegapClient) MatchmakerUpdate(ctx context.Context, name string, newName string) (*Response[Matchmaker], error) {
+	return e.Matchmaker.Update(ctx, name, newName)
+}
+
+// Delete a matchmaker.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Delete instead.
+func (e *EdgegapClient) MatchmakerDelete(ctx context.Context, name string) (*Response[interface{}], error) {
+	return e.Matchmaker.Delete(ctx, name)
+}
+
+// Retrieve a matchmaker.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Get instead.
+func (e *EdgegapClient) MatchmakerGet(ctx context.Context, name string) (*Response[Matchmaker], error) {
+	return e.Matchmaker.Get(ctx, name)
+}
+
+// Deprecated: use EdgegapClient.Matchmaker.List instead.
+func (e *EdgegapClient) MatchmakerList(ctx context.Context) (*Response[MatchmakerListRes], error) {
+	return e.Matchmaker.List(ctx)
+}
+
+// Create a matchmaker release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Releases.Create instead.
+func (e *EdgegapClient) MatchmakerCreateRelease(ctx context.Context, name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error) {
+	return e.Matchmaker.Releases.Create(ctx, name, payload)
+}
+
+// Update a matchmaker release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Releases.Update instead.
+func (e *EdgegapClient) MatchmakerUpdateRelease(ctx context.Context, name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error) {
+	return e.Matchmaker.Releases.Update(ctx, name, payload)
+}
+
+// Delete a matchmaker release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Releases.Delete instead.
+func (e *EdgegapClient) MatchmakerDeleteRelease(ctx context.Context, name string, version string) (*Response[interface{}], error) {
+	return e.Matchmaker.Releases.Delete(ctx, name, version)
+}
+
+// Retrieve a matchmaker release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Releases.Get instead.
+func (e *EdgegapClient) MatchmakerGetRelease(ctx context.Context, name string, version string) (*Response[MatchmakerRelease], error) {
+	return e.Matchmaker.Releases.Get(ctx, name, version)
+}
+
+// List all releases of a specific matchmaker.
+//
+// Deprecated: use EdgegapClient.Matchmaker.Releases.List instead.
+func (e *EdgegapClient) MatchmakerListRelease(ctx context.Context, name string) (*Response[MatchmakerReleaseListRes], error) {
+	return e.Matchmaker.Releases.List(ctx, name)
+}
+
+// Update a matchmaker managed release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ManagedReleases.Create instead.
+func (e *EdgegapClient) MatchmakerCreateManagedRelease(ctx context.Context, name string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error) {
+	return e.Matchmaker.ManagedReleases.Create(ctx, name, payload)
+}
+
+// Update a matchmaker managed release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ManagedReleases.Update instead.
+func (e *EdgegapClient) MatchmakerUpdateManagedRelease(ctx context.Context, name string, releaseVersion string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error) {
+	return e.Matchmaker.ManagedReleases.Update(ctx, name, releaseVersion, payload)
+}
+
+// Delete a matchmaker managed release. It will not delete the matchmaker.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ManagedReleases.Delete instead.
+func (e *EdgegapClient) MatchmakerDeleteManagedRelease(ctx context.Context, name string, releaseVersion string) (*Response[interface{}], error) {
+	return e.Matchmaker.ManagedReleases.Delete(ctx, name, releaseVersion)
+}
+
+// Retrieve a matchmaker managed release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ManagedReleases.Get instead.
+func (e *EdgegapClient) MatchmakerGetManagedRelease(ctx context.Context, name string, releaseVersion string) (*Response[MatchmakerManagedRelease], error) {
+	return e.Matchmaker.ManagedReleases.Get(ctx, name, releaseVersion)
+}
+
+// Create a matchmaker release config.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ReleaseConfigs.Create instead.
+func (e *EdgegapClient) MatchmakerCreateReleaseConfig(ctx context.Context, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error) {
+	return e.Matchmaker.ReleaseConfigs.Create(ctx, payload)
+}
+
+// Update a matchmaker release config.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ReleaseConfigs.Update instead.
+func (e *EdgegapClient) MatchmakerUpdateReleaseConfig(ctx context.Context, name string, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error) {
+	return e.Matchmaker.ReleaseConfigs.Update(ctx, name, payload)
+}
+
+// Delete a matchmaker release config.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ReleaseConfigs.Delete instead.
+func (e *EdgegapClient) MatchmakerDeleteReleaseConfig(ctx context.Context, name string) (*Response[interface{}], error) {
+	return e.Matchmaker.ReleaseConfigs.Delete(ctx, name)
+}
+
+// Get a matchmaker release config.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ReleaseConfigs.Get instead.
+func (e *EdgegapClient) MatchmakerGetReleaseConfig(ctx context.Context, name string) (*Response[MatchmakerReleaseConfig], error) {
+	return e.Matchmaker.ReleaseConfigs.Get(ctx, name)
+}
+
+// List all configs for a specific matchmaker release.
+//
+// Deprecated: use EdgegapClient.Matchmaker.ReleaseConfigs.List instead.
+func (e *EdgegapClient) MatchmakerListReleaseConfig(ctx context.Context) (*Response[MatchmakerReleaseConfigListRes], error) {
+	return e.Matchmaker.ReleaseConfigs.List(ctx)
+}
+
+// Get the metrics for a specific deployment based on the start_time, end_time and steps. raw parameter can be set to true to get the raw data.
+//
+// Deprecated: use EdgegapClient.Metrics.Get instead.
+func (e *EdgegapClient) MetricsByDeploymentID(ctx context.Context, id string, filter MetricsFilter) (*Response[Metrics], error) {
+	return e.Metrics.Get(ctx, id, filter)
+}
+
+// Create a session with users. Sessions are linked to a deployment.
+//
+// Deprecated: use EdgegapClient.Sessions.Create instead.
+func (e *EdgegapClient) SessionCreate(ctx context.Context, session *SessionCreate) (*Response[SessionCreateRes], error) {
+	return e.Sessions.Create(ctx, session)
+}
+
+// Delete a session. Once deleted, a session is no more accessible and does not have a history. The deployment associated will not be deleted.
+//
+// Deprecated: use EdgegapClient.Sessions.Delete instead.
+func (e *EdgegapClient) SessionDelete(ctx context.Context, id string) (*Response[SessionDeleteRes], error) {
+	return e.Sessions.Delete(ctx, id)
+}
+
+// Retrieve the information for a session.
+//
+// Deprecated: use EdgegapClient.Sessions.Get instead.
+func (e *EdgegapClient) SessionGet(ctx context.Context, id string) (*Response[Session], error) {
+	return e.Sessions.Get(ctx, id)
+}
+
+// Add specified users to a session.
+//
+// Deprecated: use EdgegapClient.Sessions.Users.Add instead.
+func (e *EdgegapClient) SessionPutUsers(ctx context.Context, id string, ips []string) (*Response[SessionUserRes], error) {
+	return e.Sessions.Users.Add(ctx, id, ips)
+}
+
+// Remove specified users from a session.
+//
+// Deprecated: use EdgegapClient.Sessions.Users.Remove instead.
+func (e *EdgegapClient) SessionDeleteUsers(ctx context.Context, id string, ips []string) (*Response[SessionUserRes], error) {
+	return e.Sessions.Users.Remove(ctx, id, ips)
+}
+
+// List all users of session.
+//
+// Deprecated: use EdgegapClient.Sessions.Users.List instead.
+func (e *EdgegapClient) SessionGetUsers(ctx context.Context, id string) (*Response[SessionUserRes], error) {
+	return e.Sessions.Users.List(ctx, id)
+}
+
+// List all the active sessions. Only the first page is returned, use SessionListPage or SessionIterate to get the others.
+//
+// Deprecated: use EdgegapClient.Sessions.List instead.
+func (e *EdgegapClient) SessionListAll(ctx context.Context) (*Response[ResponseBody[Session]], error) {
+	return e.Sessions.List(ctx)
+}
+
+// List a single page of the active sessions.
+//
+// Deprecated: use EdgegapClient.Sessions.ListPage instead.
+func (e *EdgegapClient) SessionListPage(ctx context.Context, params PaginationParams) (*Response[ResponseBody[Session]], error) {
+	return e.Sessions.ListPage(ctx, params)
+}
+
+// Iterate over every active session, fetching the pages as they are needed.
+//
+// Deprecated: use EdgegapClient.Sessions.Iterate instead.
+func (e *EdgegapClient) SessionIterate(ctx context.Context) iter.Seq2[Session, error] {
+	return e.Sessions.Iterate(ctx)
+}
+
+// Make a bulk delete of sessions using filters. All the sessions matching the given filters will be permanently deleted.
+//
+// Deprecated: use EdgegapClient.Sessions.BulkDelete instead.
+func (e *EdgegapClient) SessionBulkDelete(ctx context.Context, filters []Filter) (*Response[SessionBulkDeleteRes], error) {
+	return e.Sessions.BulkDelete(ctx, filters)
+}
+
+// Create a telemetry request to get the best deployment(s) for given IP(s). You can use this to add players on a running deployment. If you set a webhook URL, the result will be sent to it.
+//
+// Deprecated: use EdgegapClient.Telemetry.Create instead.
+func (e *EdgegapClient) TelemetryCreate(ctx context.Context, payload TelemetryCreate) (*Response[TelemetryCreateRes], error) {
+	return e.Telemetry.Create(ctx, payload)
+}
+
+// Retrieve the results of a telemetry request on active deployment(s) for given IP(s). The score array is sorted from the best to the worse deployment. You can use this to add players on a running deployment.
+//
+// Deprecated: use EdgegapClient.Telemetry.Get instead.
+func (e *EdgegapClient) TelemetryList(ctx context.Context, id string) (*Response[Telemetry], error) {
+	return e.Telemetry.Get(ctx, id)
+}
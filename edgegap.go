@@ -1,14 +1,120 @@
 package edgegap
 
-import "github.com/go-resty/resty/v2"
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
 
 type EdgegapClient struct {
-	client *resty.Client
+	Deployments  *DeploymentsService  // Deployments API
+	Sessions     *SessionsService     // Sessions API
+	Applications *ApplicationsService // Applications API, with their versions and ACL
+	Fleets       *FleetsService       // Fleets API
+	Matchmaker   *MatchmakerService   // Matchmaker API, with its components and releases
+	Metrics      *MetricsService      // Metrics API
+	Telemetry    *TelemetryService    // Telemetry API
+	Locations    *LocationsService    // Locations API
+	IP           *IPService           // IP lookup API
+
+	client  *resty.Client
+	retry   RetryPolicy
+	limiter *RateLimiter
+	crashes *CrashTracker
+	// Middlewares wrapping every call, see Use
+	middlewares []Middleware
+}
+
+type clientOptions struct {
+	baseURL     string
+	version     Version
+	httpClient  *http.Client
+	timeout     time.Duration
+	userAgent   string
+	proxyURL    string
+	retry       RetryPolicy
+	limiter     *RateLimiter
+	crashes     *CrashTracker
+	middlewares []Middleware
+}
+
+// Option configures an EdgegapClient created with NewEdgegapClient.
+type Option func(*clientOptions)
+
+// Sets the root URL of the API (i.e. a staging environment or a local fake). The API version is appended to it.
+func WithBaseURL(url string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// Sets the API version the client talks to. Defaults to VersionOne.
+func WithAPIVersion(version Version) Option {
+	return func(o *clientOptions) {
+		o.version = version
+	}
+}
+
+// Uses the given http.Client (and its transport) for every request instead of a default one.
+// The client is copied : WithTimeout and WithProxy do not change it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// Sets the overall timeout of a single HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
 }
 
-func NewEdgegapClient(token string) *EdgegapClient {
+// Routes every request through the given proxy URL. The proxy is set on a copy of the transport, which must be an
+// *http.Transport : with another transport given by WithHTTPClient (i.e. an edgegaptest.Cassette), it is ignored
+// and must be set on that transport instead.
+func WithProxy(proxyURL string) Option {
+	return func(o *clientOptions) {
+		o.proxyURL = proxyURL
+	}
+}
 
-	client := resty.New()
+// Creates a new client authenticated with token. Without options the client talks to EDGEGAP_BASE_URL using API version one.
+func NewEdgegapClient(token string, opts ...Option) *EdgegapClient {
+	options := clientOptions{
+		baseURL: EDGEGAP_BASE_URL,
+		version: VersionOne,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var client *resty.Client
+
+	proxy := options.proxyURL != ""
+
+	if options.httpClient != nil {
+		// Copied, so that the timeout, proxy and transport resty sets do not change the caller's client.
+		httpClient := *options.httpClient
+
+		if proxy {
+			httpClient.Transport, proxy = cloneTransport(httpClient.Transport)
+		}
+
+		client = resty.NewWithClient(&httpClient)
+	} else {
+		client = resty.New()
+	}
 
 	client.SetHeaders(
 		map[string]string{
@@ -17,9 +123,65 @@ func NewEdgegapClient(token string) *EdgegapClient {
 		},
 	)
 
-	client.SetBaseURL(EDGEGAP_BASE_URL + "/" + string(VersionOne))
+	if options.userAgent != "" {
+		client.SetHeader("User-Agent", options.userAgent)
+	}
+
+	if options.timeout > 0 {
+		client.SetTimeout(options.timeout)
+	}
 
-	return &EdgegapClient{
-		client: client,
+	if proxy {
+		client.SetProxy(options.proxyURL)
 	}
+
+	client.SetBaseURL(options.baseURL + "/" + string(options.version))
+
+	e := &EdgegapClient{
+		client:  client,
+		retry:   options.retry,
+		limiter: options.limiter,
+		crashes: options.crashes,
+		// Copied so that Use does not alter the options slice
+		middlewares: append([]Middleware(nil), options.middlewares...),
+	}
+
+	e.Deployments = &DeploymentsService{client: e}
+	e.Sessions = &SessionsService{client: e, Users: &SessionUsersService{client: e}}
+	e.Applications = &ApplicationsService{
+		client: e,
+		Versions: &ApplicationVersionsService{
+			client: e,
+			ACL:    &ApplicationACLService{client: e},
+		},
+	}
+	e.Fleets = &FleetsService{client: e}
+	e.Matchmaker = &MatchmakerService{
+		client: e,
+		Components: &MatchmakerComponentsService{
+			client: e,
+			Envs:   &MatchmakerEnvsService{client: e},
+		},
+		Releases:        &MatchmakerReleasesService{client: e},
+		ManagedReleases: &MatchmakerManagedReleasesService{client: e},
+		ReleaseConfigs:  &MatchmakerReleaseConfigsService{client: e},
+	}
+	e.Metrics = &MetricsService{client: e}
+	e.Telemetry = &TelemetryService{client: e}
+	e.Locations = &LocationsService{client: e}
+	e.IP = &IPService{client: e}
+
+	return e
+}
+
+// cloneTransport returns a copy of transport a proxy can be set on, and false when transport is not an *http.Transport.
+func cloneTransport(transport http.RoundTripper) (http.RoundTripper, bool) {
+	switch t := transport.(type) {
+	case nil:
+		return http.DefaultTransport.(*http.Transport).Clone(), true
+	case *http.Transport:
+		return t.Clone(), true
+	}
+
+	return transport, false
 }
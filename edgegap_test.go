@@ -0,0 +1,143 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// newStubClient starts a server answering every request with handler and returns a client talking to it.
+func newStubClient(t *testing.T, handler http.HandlerFunc, opts ...edgegap.Option) *edgegap.EdgegapClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return edgegap.NewEdgegapClient("token test", append([]edgegap.Option{edgegap.WithBaseURL(server.URL)}, opts...)...)
+}
+
+// publicIP answers the requests of IPGet.
+func publicIP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"public_ip":"203.0.113.1"}`)
+}
+
+// slow answers after a second, or when the client gives up.
+func slow(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(time.Second):
+		publicIP(w, r)
+	}
+}
+
+func TestNewEdgegapClientOptions(t *testing.T) {
+	var path, userAgent, authorization, transported string
+
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, userAgent, authorization = r.URL.Path, r.UserAgent(), r.Header.Get("Authorization")
+		publicIP(w, r)
+	},
+		edgegap.WithUserAgent("edgegap-test/1.0"),
+		edgegap.WithHTTPClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			transported = r.URL.Path
+			return http.DefaultTransport.RoundTrip(r)
+		})}),
+	)
+
+	res, err := client.IPGet(context.Background())
+	if err != nil {
+		t.Fatalf("IPGet() error = %v", err)
+	}
+
+	if res.Data.IP != "203.0.113.1" {
+		t.Errorf("IPGet() = %+v, want the stubbed IP", res.Data)
+	}
+
+	if path != "/v1/ip" || transported != path {
+		t.Errorf("path = %q through the transport %q, want /v1/ip through the given HTTP client", path, transported)
+	}
+
+	if userAgent != "edgegap-test/1.0" || authorization != "token test" {
+		t.Errorf("User-Agent = %q, Authorization = %q, want the configured ones", userAgent, authorization)
+	}
+}
+
+func TestNewEdgegapClientTimeout(t *testing.T) {
+	client := newStubClient(t, slow, edgegap.WithTimeout(50*time.Millisecond))
+
+	if _, err := client.IPGet(context.Background()); err == nil {
+		t.Fatal("IPGet() error = nil, want a timeout")
+	}
+}
+
+func TestNewEdgegapClientCopiesHTTPClient(t *testing.T) {
+	server, _ := newTestClient(t)
+
+	transport := &http.Transport{}
+	httpClient := &http.Client{Transport: transport}
+
+	// The fake answers as the proxy, the base URL being unreachable.
+	client := server.Client(
+		edgegap.WithBaseURL("http://edgegap.invalid"),
+		edgegap.WithHTTPClient(httpClient),
+		edgegap.WithTimeout(time.Second),
+		edgegap.WithProxy(server.URL),
+	)
+
+	if _, err := client.IP.Get(context.Background()); err != nil {
+		t.Fatalf("IP.Get() through the proxy error = %v", err)
+	}
+
+	if httpClient.Timeout != 0 || httpClient.Transport != transport || transport.Proxy != nil {
+		t.Errorf("http.Client after NewEdgegapClient() = %+v, want it unchanged", httpClient)
+	}
+
+	bare := &http.Client{}
+	edgegap.NewEdgegapClient("token", edgegap.WithHTTPClient(bare), edgegap.WithTimeout(time.Second))
+
+	if bare.Timeout != 0 || bare.Transport != nil {
+		t.Errorf("http.Client after NewEdgegapClient() = %+v, want it unchanged", bare)
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	client := edgegap.NewEdgegapClient("token test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A cancelled context stops the call before it reaches the network.
+	if _, err := client.IPGet(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("IPGet() error = %v, want the context error", err)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	client := newStubClient(t, slow)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := client.IPGet(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("IPGet() error = %v, want the context error", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("IPGet() returned after %s, want it to stop at the deadline", elapsed)
+	}
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,185 @@
+package edgegapmock
+
+import (
+	"context"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Applications is a programmable mock of edgegap.ApplicationsAPI.
+type Applications struct {
+	recorder
+
+	CreateFunc func(context.Context, edgegap.ApplicationCreate) (*edgegap.Response[edgegap.Application], error)
+	UpdateFunc func(context.Context, string, edgegap.ApplicationCreate) (*edgegap.Response[edgegap.Application], error)
+	DeleteFunc func(context.Context, string) (*edgegap.Response[map[string]interface{}], error)
+	GetFunc    func(context.Context, string) (*edgegap.Response[edgegap.Application], error)
+	ListFunc   func(context.Context) (*edgegap.Response[edgegap.ApplicationList], error)
+}
+
+var _ edgegap.ApplicationsAPI = (*Applications)(nil)
+
+func (m *Applications) Create(ctx context.Context, application edgegap.ApplicationCreate) (*edgegap.Response[edgegap.Application], error) {
+	m.record("Create", application)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, application)
+}
+
+func (m *Applications) Update(ctx context.Context, name string, application edgegap.ApplicationCreate) (*edgegap.Response[edgegap.Application], error) {
+	m.record("Update", name, application)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, application)
+}
+
+func (m *Applications) Delete(ctx context.Context, name string) (*edgegap.Response[map[string]interface{}], error) {
+	m.record("Delete", name)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name)
+}
+
+func (m *Applications) Get(ctx context.Context, name string) (*edgegap.Response[edgegap.Application], error) {
+	m.record("Get", name)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name)
+}
+
+func (m *Applications) List(ctx context.Context) (*edgegap.Response[edgegap.ApplicationList], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+// ApplicationVersions is a programmable mock of edgegap.ApplicationVersionsAPI.
+type ApplicationVersions struct {
+	recorder
+
+	CreateFunc func(context.Context, string, edgegap.ApplicationVersion) (*edgegap.Response[edgegap.ApplicationVersionCreateResponse], error)
+	DeleteFunc func(context.Context, string, string) (*edgegap.Response[map[string]interface{}], error)
+	GetFunc    func(context.Context, string, string) (*edgegap.Response[edgegap.ApplicationVersion], error)
+	UpdateFunc func(context.Context, string, string, edgegap.ApplicationVersion) (*edgegap.Response[edgegap.ApplicationVersionCreateResponse], error)
+	ListFunc   func(context.Context, string) (*edgegap.Response[edgegap.ApplicationVersionList], error)
+}
+
+var _ edgegap.ApplicationVersionsAPI = (*ApplicationVersions)(nil)
+
+func (m *ApplicationVersions) Create(ctx context.Context, appName string, version edgegap.ApplicationVersion) (*edgegap.Response[edgegap.ApplicationVersionCreateResponse], error) {
+	m.record("Create", appName, version)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, appName, version)
+}
+
+func (m *ApplicationVersions) Delete(ctx context.Context, appName string, version string) (*edgegap.Response[map[string]interface{}], error) {
+	m.record("Delete", appName, version)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, appName, version)
+}
+
+func (m *ApplicationVersions) Get(ctx context.Context, appName string, version string) (*edgegap.Response[edgegap.ApplicationVersion], error) {
+	m.record("Get", appName, version)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, appName, version)
+}
+
+func (m *ApplicationVersions) Update(ctx context.Context, appName string, version string, data edgegap.ApplicationVersion) (*edgegap.Response[edgegap.ApplicationVersionCreateResponse], error) {
+	m.record("Update", appName, version, data)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, appName, version, data)
+}
+
+func (m *ApplicationVersions) List(ctx context.Context, appName string) (*edgegap.Response[edgegap.ApplicationVersionList], error) {
+	m.record("List", appName)
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx, appName)
+}
+
+// ApplicationACL is a programmable mock of edgegap.ApplicationACLAPI.
+type ApplicationACL struct {
+	recorder
+
+	CreateFunc func(context.Context, string, string, edgegap.ApplicationACL) (*edgegap.Response[edgegap.ApplicationACLCreateResponse], error)
+	ListFunc   func(context.Context, string, string) (*edgegap.Response[edgegap.ApplicationACLEntries], error)
+	DeleteFunc func(context.Context, string, string, string) (*edgegap.Response[edgegap.ApplicationACLCreateResponse], error)
+	GetFunc    func(context.Context, string, string, string) (*edgegap.Response[edgegap.ApplicationACL], error)
+}
+
+var _ edgegap.ApplicationACLAPI = (*ApplicationACL)(nil)
+
+func (m *ApplicationACL) Create(ctx context.Context, appName string, version string, data edgegap.ApplicationACL) (*edgegap.Response[edgegap.ApplicationACLCreateResponse], error) {
+	m.record("Create", appName, version, data)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, appName, version, data)
+}
+
+func (m *ApplicationACL) List(ctx context.Context, appName string, version string) (*edgegap.Response[edgegap.ApplicationACLEntries], error) {
+	m.record("List", appName, version)
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx, appName, version)
+}
+
+func (m *ApplicationACL) Delete(ctx context.Context, appName string, version string, entryId string) (*edgegap.Response[edgegap.ApplicationACLCreateResponse], error) {
+	m.record("Delete", appName, version, entryId)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, appName, version, entryId)
+}
+
+func (m *ApplicationACL) Get(ctx context.Context, appName string, version string, entryId string) (*edgegap.Response[edgegap.ApplicationACL], error) {
+	m.record("Get", appName, version, entryId)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, appName, version, entryId)
+}
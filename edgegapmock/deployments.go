@@ -0,0 +1,159 @@
+package edgegapmock
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Deployments is a programmable mock of edgegap.DeploymentsAPI.
+type Deployments struct {
+	recorder
+
+	CreateFunc      func(context.Context, *edgegap.DeployementCreatePayload) (*edgegap.Response[edgegap.DeploymentCreateResponse], error)
+	LogsFunc        func(context.Context, string) (*edgegap.Response[edgegap.DeploymentContainerLogs], error)
+	ListFunc        func(context.Context) (*edgegap.Response[edgegap.ResponseBody[edgegap.Deployment]], error)
+	ListPageFunc    func(context.Context, edgegap.PaginationParams) (*edgegap.Response[edgegap.ResponseBody[edgegap.Deployment]], error)
+	IterateFunc     func(context.Context) iter.Seq2[edgegap.Deployment, error]
+	BulkDeleteFunc  func(context.Context, []edgegap.Filter) (*edgegap.Response[edgegap.DeploymentBulkDelete], error)
+	StopFunc        func(context.Context, string) (*edgegap.Response[edgegap.DeploymentStopResponse], error)
+	SelfStopFunc    func(context.Context, string, string) (*edgegap.Response[edgegap.DeploymentStopResponse], error)
+	SelfContextFunc func(context.Context, string, string) (*edgegap.Response[edgegap.DeploymentInfo], error)
+	UpdateFunc      func(context.Context, string, bool) (*edgegap.Response[edgegap.DeploymentUpdateResponse], error)
+	AvailableFunc   func(context.Context, edgegap.DeploymentAvailableSocketPayload) (*edgegap.Response[edgegap.ResponseBody[edgegap.Deployment]], error)
+	GetFunc         func(context.Context, string) (*edgegap.Response[edgegap.DeploymentInfo], error)
+	CreateFromFunc  func(context.Context, *edgegap.DeploymentRequest) (*edgegap.Response[edgegap.DeploymentCreateResponse], error)
+}
+
+var _ edgegap.DeploymentsAPI = (*Deployments)(nil)
+
+func (m *Deployments) Create(ctx context.Context, data *edgegap.DeployementCreatePayload) (*edgegap.Response[edgegap.DeploymentCreateResponse], error) {
+	m.record("Create", data)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, data)
+}
+
+func (m *Deployments) Logs(ctx context.Context, requestId string) (*edgegap.Response[edgegap.DeploymentContainerLogs], error) {
+	m.record("Logs", requestId)
+
+	if m.LogsFunc == nil {
+		return nil, notMocked("Logs")
+	}
+
+	return m.LogsFunc(ctx, requestId)
+}
+
+func (m *Deployments) List(ctx context.Context) (*edgegap.Response[edgegap.ResponseBody[edgegap.Deployment]], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+func (m *Deployments) ListPage(ctx context.Context, params edgegap.PaginationParams) (*edgegap.Response[edgegap.ResponseBody[edgegap.Deployment]], error) {
+	m.record("ListPage", params)
+
+	if m.ListPageFunc == nil {
+		return nil, notMocked("ListPage")
+	}
+
+	return m.ListPageFunc(ctx, params)
+}
+
+func (m *Deployments) Iterate(ctx context.Context) iter.Seq2[edgegap.Deployment, error] {
+	m.record("Iterate")
+
+	if m.IterateFunc == nil {
+		return notMockedSeq[edgegap.Deployment]("Iterate")
+	}
+
+	return m.IterateFunc(ctx)
+}
+
+func (m *Deployments) BulkDelete(ctx context.Context, filters []edgegap.Filter) (*edgegap.Response[edgegap.DeploymentBulkDelete], error) {
+	m.record("BulkDelete", filters)
+
+	if m.BulkDeleteFunc == nil {
+		return nil, notMocked("BulkDelete")
+	}
+
+	return m.BulkDeleteFunc(ctx, filters)
+}
+
+func (m *Deployments) Stop(ctx context.Context, requestId string) (*edgegap.Response[edgegap.DeploymentStopResponse], error) {
+	m.record("Stop", requestId)
+
+	if m.StopFunc == nil {
+		return nil, notMocked("Stop")
+	}
+
+	return m.StopFunc(ctx, requestId)
+}
+
+func (m *Deployments) SelfStop(ctx context.Context, deleteURL string, deleteToken string) (*edgegap.Response[edgegap.DeploymentStopResponse], error) {
+	m.record("SelfStop", deleteURL, deleteToken)
+
+	if m.SelfStopFunc == nil {
+		return nil, notMocked("SelfStop")
+	}
+
+	return m.SelfStopFunc(ctx, deleteURL, deleteToken)
+}
+
+func (m *Deployments) SelfContext(ctx context.Context, contextURL string, contextToken string) (*edgegap.Response[edgegap.DeploymentInfo], error) {
+	m.record("SelfContext", contextURL, contextToken)
+
+	if m.SelfContextFunc == nil {
+		return nil, notMocked("SelfContext")
+	}
+
+	return m.SelfContextFunc(ctx, contextURL, contextToken)
+}
+
+func (m *Deployments) Update(ctx context.Context, requestId string, isJoinableSession bool) (*edgegap.Response[edgegap.DeploymentUpdateResponse], error) {
+	m.record("Update", requestId, isJoinableSession)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, requestId, isJoinableSession)
+}
+
+func (m *Deployments) Available(ctx context.Context, data edgegap.DeploymentAvailableSocketPayload) (*edgegap.Response[edgegap.ResponseBody[edgegap.Deployment]], error) {
+	m.record("Available", data)
+
+	if m.AvailableFunc == nil {
+		return nil, notMocked("Available")
+	}
+
+	return m.AvailableFunc(ctx, data)
+}
+
+func (m *Deployments) Get(ctx context.Context, request_id string) (*edgegap.Response[edgegap.DeploymentInfo], error) {
+	m.record("Get", request_id)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, request_id)
+}
+
+func (m *Deployments) CreateFrom(ctx context.Context, request *edgegap.DeploymentRequest) (*edgegap.Response[edgegap.DeploymentCreateResponse], error) {
+	m.record("CreateFrom", request)
+
+	if m.CreateFromFunc == nil {
+		return nil, notMocked("CreateFrom")
+	}
+
+	return m.CreateFromFunc(ctx, request)
+}
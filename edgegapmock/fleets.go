@@ -0,0 +1,115 @@
+package edgegapmock
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Fleets is a programmable mock of edgegap.FleetsAPI.
+type Fleets struct {
+	recorder
+
+	CreateFunc   func(context.Context, edgegap.FleetCreatePayload) (*edgegap.Response[edgegap.Fleet], error)
+	GetFunc      func(context.Context, string) (*edgegap.Response[edgegap.Fleet], error)
+	UpdateFunc   func(context.Context, string, edgegap.FleetCreatePayload) (*edgegap.Response[edgegap.Fleet], error)
+	DeleteFunc   func(context.Context, string) (*edgegap.Response[interface{}], error)
+	ListFunc     func(context.Context) (*edgegap.Response[edgegap.FleetList], error)
+	ListPageFunc func(context.Context, edgegap.PaginationParams) (*edgegap.Response[edgegap.FleetList], error)
+	IterateFunc  func(context.Context) iter.Seq2[edgegap.Fleet, error]
+	LinkFunc     func(context.Context, string, string, string) (*edgegap.Response[edgegap.FleetApplication], error)
+	UnlinkFunc   func(context.Context, string, string, string) (*edgegap.Response[interface{}], error)
+}
+
+var _ edgegap.FleetsAPI = (*Fleets)(nil)
+
+func (m *Fleets) Create(ctx context.Context, payload edgegap.FleetCreatePayload) (*edgegap.Response[edgegap.Fleet], error) {
+	m.record("Create", payload)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, payload)
+}
+
+func (m *Fleets) Get(ctx context.Context, name string) (*edgegap.Response[edgegap.Fleet], error) {
+	m.record("Get", name)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name)
+}
+
+func (m *Fleets) Update(ctx context.Context, name string, payload edgegap.FleetCreatePayload) (*edgegap.Response[edgegap.Fleet], error) {
+	m.record("Update", name, payload)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, payload)
+}
+
+func (m *Fleets) Delete(ctx context.Context, name string) (*edgegap.Response[interface{}], error) {
+	m.record("Delete", name)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name)
+}
+
+func (m *Fleets) List(ctx context.Context) (*edgegap.Response[edgegap.FleetList], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+func (m *Fleets) ListPage(ctx context.Context, params edgegap.PaginationParams) (*edgegap.Response[edgegap.FleetList], error) {
+	m.record("ListPage", params)
+
+	if m.ListPageFunc == nil {
+		return nil, notMocked("ListPage")
+	}
+
+	return m.ListPageFunc(ctx, params)
+}
+
+func (m *Fleets) Iterate(ctx context.Context) iter.Seq2[edgegap.Fleet, error] {
+	m.record("Iterate")
+
+	if m.IterateFunc == nil {
+		return notMockedSeq[edgegap.Fleet]("Iterate")
+	}
+
+	return m.IterateFunc(ctx)
+}
+
+func (m *Fleets) Link(ctx context.Context, fleet string, app string, version string) (*edgegap.Response[edgegap.FleetApplication], error) {
+	m.record("Link", fleet, app, version)
+
+	if m.LinkFunc == nil {
+		return nil, notMocked("Link")
+	}
+
+	return m.LinkFunc(ctx, fleet, app, version)
+}
+
+func (m *Fleets) Unlink(ctx context.Context, fleet string, app string, version string) (*edgegap.Response[interface{}], error) {
+	m.record("Unlink", fleet, app, version)
+
+	if m.UnlinkFunc == nil {
+		return nil, notMocked("Unlink")
+	}
+
+	return m.UnlinkFunc(ctx, fleet, app, version)
+}
@@ -0,0 +1,48 @@
+package edgegapmock
+
+import (
+	"context"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// IP is a programmable mock of edgegap.IPAPI.
+type IP struct {
+	recorder
+
+	GetFunc        func(context.Context) (*edgegap.Response[edgegap.PublicIPResponse], error)
+	LookupFunc     func(context.Context, string) (*edgegap.Response[edgegap.IPInformation], error)
+	LookupBulkFunc func(context.Context, edgegap.IPBulkInfoPayload) (*edgegap.Response[edgegap.IPBulkInfo], error)
+}
+
+var _ edgegap.IPAPI = (*IP)(nil)
+
+func (m *IP) Get(ctx context.Context) (*edgegap.Response[edgegap.PublicIPResponse], error) {
+	m.record("Get")
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx)
+}
+
+func (m *IP) Lookup(ctx context.Context, ip string) (*edgegap.Response[edgegap.IPInformation], error) {
+	m.record("Lookup", ip)
+
+	if m.LookupFunc == nil {
+		return nil, notMocked("Lookup")
+	}
+
+	return m.LookupFunc(ctx, ip)
+}
+
+func (m *IP) LookupBulk(ctx context.Context, payload edgegap.IPBulkInfoPayload) (*edgegap.Response[edgegap.IPBulkInfo], error) {
+	m.record("LookupBulk", payload)
+
+	if m.LookupBulkFunc == nil {
+		return nil, notMocked("LookupBulk")
+	}
+
+	return m.LookupBulkFunc(ctx, payload)
+}
@@ -0,0 +1,37 @@
+package edgegapmock
+
+import (
+	"context"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Locations is a programmable mock of edgegap.LocationsAPI.
+type Locations struct {
+	recorder
+
+	ListFunc    func(context.Context, edgegap.LocationFilters) (*edgegap.Response[edgegap.LocationListRes], error)
+	BeaconsFunc func(context.Context) (*edgegap.Response[edgegap.LocationBeaconRes], error)
+}
+
+var _ edgegap.LocationsAPI = (*Locations)(nil)
+
+func (m *Locations) List(ctx context.Context, filters edgegap.LocationFilters) (*edgegap.Response[edgegap.LocationListRes], error) {
+	m.record("List", filters)
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx, filters)
+}
+
+func (m *Locations) Beacons(ctx context.Context) (*edgegap.Response[edgegap.LocationBeaconRes], error) {
+	m.record("Beacons")
+
+	if m.BeaconsFunc == nil {
+		return nil, notMocked("Beacons")
+	}
+
+	return m.BeaconsFunc(ctx)
+}
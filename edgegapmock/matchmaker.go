@@ -0,0 +1,374 @@
+package edgegapmock
+
+import (
+	"context"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Matchmaker is a programmable mock of edgegap.MatchmakerAPI.
+type Matchmaker struct {
+	recorder
+
+	CreateFunc func(context.Context, string) (*edgegap.Response[edgegap.Matchmaker], error)
+	UpdateFunc func(context.Context, string, string) (*edgegap.Response[edgegap.Matchmaker], error)
+	DeleteFunc func(context.Context, string) (*edgegap.Response[interface{}], error)
+	GetFunc    func(context.Context, string) (*edgegap.Response[edgegap.Matchmaker], error)
+	ListFunc   func(context.Context) (*edgegap.Response[edgegap.MatchmakerListRes], error)
+}
+
+var _ edgegap.MatchmakerAPI = (*Matchmaker)(nil)
+
+func (m *Matchmaker) Create(ctx context.Context, name string) (*edgegap.Response[edgegap.Matchmaker], error) {
+	m.record("Create", name)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, name)
+}
+
+func (m *Matchmaker) Update(ctx context.Context, name string, newName string) (*edgegap.Response[edgegap.Matchmaker], error) {
+	m.record("Update", name, newName)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, newName)
+}
+
+func (m *Matchmaker) Delete(ctx context.Context, name string) (*edgegap.Response[interface{}], error) {
+	m.record("Delete", name)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name)
+}
+
+func (m *Matchmaker) Get(ctx context.Context, name string) (*edgegap.Response[edgegap.Matchmaker], error) {
+	m.record("Get", name)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name)
+}
+
+func (m *Matchmaker) List(ctx context.Context) (*edgegap.Response[edgegap.MatchmakerListRes], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+// MatchmakerComponents is a programmable mock of edgegap.MatchmakerComponentsAPI.
+type MatchmakerComponents struct {
+	recorder
+
+	CreateFunc func(context.Context, edgegap.MatchmakerComponentCreate) (*edgegap.Response[edgegap.MatchmakerComponent], error)
+	UpdateFunc func(context.Context, string, edgegap.MatchmakerComponentCreate) (*edgegap.Response[edgegap.MatchmakerComponent], error)
+	DeleteFunc func(context.Context, string) (*edgegap.Response[map[string]string], error)
+	GetFunc    func(context.Context, string) (*edgegap.Response[edgegap.MatchmakerComponent], error)
+	ListFunc   func(context.Context) (*edgegap.Response[edgegap.MatchmakerComponentListRes], error)
+}
+
+var _ edgegap.MatchmakerComponentsAPI = (*MatchmakerComponents)(nil)
+
+func (m *MatchmakerComponents) Create(ctx context.Context, component edgegap.MatchmakerComponentCreate) (*edgegap.Response[edgegap.MatchmakerComponent], error) {
+	m.record("Create", component)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, component)
+}
+
+func (m *MatchmakerComponents) Update(ctx context.Context, name string, component edgegap.MatchmakerComponentCreate) (*edgegap.Response[edgegap.MatchmakerComponent], error) {
+	m.record("Update", name, component)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, component)
+}
+
+func (m *MatchmakerComponents) Delete(ctx context.Context, name string) (*edgegap.Response[map[string]string], error) {
+	m.record("Delete", name)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name)
+}
+
+func (m *MatchmakerComponents) Get(ctx context.Context, name string) (*edgegap.Response[edgegap.MatchmakerComponent], error) {
+	m.record("Get", name)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name)
+}
+
+func (m *MatchmakerComponents) List(ctx context.Context) (*edgegap.Response[edgegap.MatchmakerComponentListRes], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+// MatchmakerEnvs is a programmable mock of edgegap.MatchmakerEnvsAPI.
+type MatchmakerEnvs struct {
+	recorder
+
+	CreateFunc func(context.Context, string, edgegap.MatchmakerEnv) (*edgegap.Response[edgegap.MatchmakerEnvRes], error)
+	UpdateFunc func(context.Context, string, edgegap.MatchmakerEnv) (*edgegap.Response[edgegap.MatchmakerEnvRes], error)
+	DeleteFunc func(context.Context, string, string) (*edgegap.Response[map[string]string], error)
+	GetFunc    func(context.Context, string, string) (*edgegap.Response[edgegap.MatchmakerEnvRes], error)
+	ListFunc   func(context.Context, string) (*edgegap.Response[edgegap.MatchmakerEnvListRes], error)
+}
+
+var _ edgegap.MatchmakerEnvsAPI = (*MatchmakerEnvs)(nil)
+
+func (m *MatchmakerEnvs) Create(ctx context.Context, name string, env edgegap.MatchmakerEnv) (*edgegap.Response[edgegap.MatchmakerEnvRes], error) {
+	m.record("Create", name, env)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, name, env)
+}
+
+func (m *MatchmakerEnvs) Update(ctx context.Context, name string, env edgegap.MatchmakerEnv) (*edgegap.Response[edgegap.MatchmakerEnvRes], error) {
+	m.record("Update", name, env)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, env)
+}
+
+func (m *MatchmakerEnvs) Delete(ctx context.Context, name string, env string) (*edgegap.Response[map[string]string], error) {
+	m.record("Delete", name, env)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name, env)
+}
+
+func (m *MatchmakerEnvs) Get(ctx context.Context, name string, env string) (*edgegap.Response[edgegap.MatchmakerEnvRes], error) {
+	m.record("Get", name, env)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name, env)
+}
+
+func (m *MatchmakerEnvs) List(ctx context.Context, name string) (*edgegap.Response[edgegap.MatchmakerEnvListRes], error) {
+	m.record("List", name)
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx, name)
+}
+
+// MatchmakerReleases is a programmable mock of edgegap.MatchmakerReleasesAPI.
+type MatchmakerReleases struct {
+	recorder
+
+	CreateFunc func(context.Context, string, edgegap.MatchmakerReleaseCreate) (*edgegap.Response[edgegap.MatchmakerRelease], error)
+	UpdateFunc func(context.Context, string, edgegap.MatchmakerReleaseCreate) (*edgegap.Response[edgegap.MatchmakerRelease], error)
+	DeleteFunc func(context.Context, string, string) (*edgegap.Response[interface{}], error)
+	GetFunc    func(context.Context, string, string) (*edgegap.Response[edgegap.MatchmakerRelease], error)
+	ListFunc   func(context.Context, string) (*edgegap.Response[edgegap.MatchmakerReleaseListRes], error)
+}
+
+var _ edgegap.MatchmakerReleasesAPI = (*MatchmakerReleases)(nil)
+
+func (m *MatchmakerReleases) Create(ctx context.Context, name string, payload edgegap.MatchmakerReleaseCreate) (*edgegap.Response[edgegap.MatchmakerRelease], error) {
+	m.record("Create", name, payload)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, name, payload)
+}
+
+func (m *MatchmakerReleases) Update(ctx context.Context, name string, payload edgegap.MatchmakerReleaseCreate) (*edgegap.Response[edgegap.MatchmakerRelease], error) {
+	m.record("Update", name, payload)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, payload)
+}
+
+func (m *MatchmakerReleases) Delete(ctx context.Context, name string, version string) (*edgegap.Response[interface{}], error) {
+	m.record("Delete", name, version)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name, version)
+}
+
+func (m *MatchmakerReleases) Get(ctx context.Context, name string, version string) (*edgegap.Response[edgegap.MatchmakerRelease], error) {
+	m.record("Get", name, version)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name, version)
+}
+
+func (m *MatchmakerReleases) List(ctx context.Context, name string) (*edgegap.Response[edgegap.MatchmakerReleaseListRes], error) {
+	m.record("List", name)
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx, name)
+}
+
+// MatchmakerManagedReleases is a programmable mock of edgegap.MatchmakerManagedReleasesAPI.
+type MatchmakerManagedReleases struct {
+	recorder
+
+	CreateFunc func(context.Context, string, edgegap.MatchmakerManagedReleaseCreate) (*edgegap.Response[edgegap.MatchmakerManagedRelease], error)
+	UpdateFunc func(context.Context, string, string, edgegap.MatchmakerManagedReleaseCreate) (*edgegap.Response[edgegap.MatchmakerManagedRelease], error)
+	DeleteFunc func(context.Context, string, string) (*edgegap.Response[interface{}], error)
+	GetFunc    func(context.Context, string, string) (*edgegap.Response[edgegap.MatchmakerManagedRelease], error)
+}
+
+var _ edgegap.MatchmakerManagedReleasesAPI = (*MatchmakerManagedReleases)(nil)
+
+func (m *MatchmakerManagedReleases) Create(ctx context.Context, name string, payload edgegap.MatchmakerManagedReleaseCreate) (*edgegap.Response[edgegap.MatchmakerManagedRelease], error) {
+	m.record("Create", name, payload)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, name, payload)
+}
+
+func (m *MatchmakerManagedReleases) Update(ctx context.Context, name string, releaseVersion string, payload edgegap.MatchmakerManagedReleaseCreate) (*edgegap.Response[edgegap.MatchmakerManagedRelease], error) {
+	m.record("Update", name, releaseVersion, payload)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, releaseVersion, payload)
+}
+
+func (m *MatchmakerManagedReleases) Delete(ctx context.Context, name string, releaseVersion string) (*edgegap.Response[interface{}], error) {
+	m.record("Delete", name, releaseVersion)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name, releaseVersion)
+}
+
+func (m *MatchmakerManagedReleases) Get(ctx context.Context, name string, releaseVersion string) (*edgegap.Response[edgegap.MatchmakerManagedRelease], error) {
+	m.record("Get", name, releaseVersion)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name, releaseVersion)
+}
+
+// MatchmakerReleaseConfigs is a programmable mock of edgegap.MatchmakerReleaseConfigsAPI.
+type MatchmakerReleaseConfigs struct {
+	recorder
+
+	CreateFunc func(context.Context, edgegap.MatchmakerReleaseConfig) (*edgegap.Response[edgegap.MatchmakerReleaseConfig], error)
+	UpdateFunc func(context.Context, string, edgegap.MatchmakerReleaseConfig) (*edgegap.Response[edgegap.MatchmakerReleaseConfig], error)
+	DeleteFunc func(context.Context, string) (*edgegap.Response[interface{}], error)
+	GetFunc    func(context.Context, string) (*edgegap.Response[edgegap.MatchmakerReleaseConfig], error)
+	ListFunc   func(context.Context) (*edgegap.Response[edgegap.MatchmakerReleaseConfigListRes], error)
+}
+
+var _ edgegap.MatchmakerReleaseConfigsAPI = (*MatchmakerReleaseConfigs)(nil)
+
+func (m *MatchmakerReleaseConfigs) Create(ctx context.Context, payload edgegap.MatchmakerReleaseConfig) (*edgegap.Response[edgegap.MatchmakerReleaseConfig], error) {
+	m.record("Create", payload)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, payload)
+}
+
+func (m *MatchmakerReleaseConfigs) Update(ctx context.Context, name string, payload edgegap.MatchmakerReleaseConfig) (*edgegap.Response[edgegap.MatchmakerReleaseConfig], error) {
+	m.record("Update", name, payload)
+
+	if m.UpdateFunc == nil {
+		return nil, notMocked("Update")
+	}
+
+	return m.UpdateFunc(ctx, name, payload)
+}
+
+func (m *MatchmakerReleaseConfigs) Delete(ctx context.Context, name string) (*edgegap.Response[interface{}], error) {
+	m.record("Delete", name)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, name)
+}
+
+func (m *MatchmakerReleaseConfigs) Get(ctx context.Context, name string) (*edgegap.Response[edgegap.MatchmakerReleaseConfig], error) {
+	m.record("Get", name)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, name)
+}
+
+func (m *MatchmakerReleaseConfigs) List(ctx context.Context) (*edgegap.Response[edgegap.MatchmakerReleaseConfigListRes], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
@@ -0,0 +1,26 @@
+package edgegapmock
+
+import (
+	"context"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Metrics is a programmable mock of edgegap.MetricsAPI.
+type Metrics struct {
+	recorder
+
+	GetFunc func(context.Context, string, edgegap.MetricsFilter) (*edgegap.Response[edgegap.Metrics], error)
+}
+
+var _ edgegap.MetricsAPI = (*Metrics)(nil)
+
+func (m *Metrics) Get(ctx context.Context, id string, filter edgegap.MetricsFilter) (*edgegap.Response[edgegap.Metrics], error) {
+	m.record("Get", id, filter)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, id, filter)
+}
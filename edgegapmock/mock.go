@@ -0,0 +1,138 @@
+// Package edgegapmock provides programmable mocks of the service interfaces of edgegap.API,
+// to test code depending on them without a server.
+//
+// Every mock has a function field per method, named after the method with a Func suffix, called with the
+// arguments of the method. A method whose function is not set fails with ErrNotMocked.
+// The calls made to a mock are recorded and can be inspected with Calls.
+package edgegapmock
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// ErrNotMocked is returned by the methods whose function is not set.
+var ErrNotMocked = errors.New("edgegapmock: method not mocked")
+
+// Call is a call made to a mock.
+type Call struct {
+	Method string        // Name of the method called
+	Args   []interface{} // Arguments of the call, without the context
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Returns the calls made to the mock so far, oldest first.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// Returns the number of calls made to method.
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+
+	for _, call := range r.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Client mocks every service of edgegap.API. Its calls are recorded by the mock of each service.
+type Client struct {
+	Deployments               Deployments
+	Sessions                  Sessions
+	SessionUsers              SessionUsers
+	Applications              Applications
+	ApplicationVersions       ApplicationVersions
+	ApplicationACL            ApplicationACL
+	Fleets                    Fleets
+	Matchmaker                Matchmaker
+	MatchmakerComponents      MatchmakerComponents
+	MatchmakerEnvs            MatchmakerEnvs
+	MatchmakerReleases        MatchmakerReleases
+	MatchmakerManagedReleases MatchmakerManagedReleases
+	MatchmakerReleaseConfigs  MatchmakerReleaseConfigs
+	Metrics                   Metrics
+	Telemetry                 Telemetry
+	Locations                 Locations
+	IP                        IP
+}
+
+// Returns the mocks behind the interfaces of edgegap.API, to hand to the code under test.
+func (c *Client) API() edgegap.API {
+	return edgegap.API{
+		Deployments:               &c.Deployments,
+		Sessions:                  &c.Sessions,
+		SessionUsers:              &c.SessionUsers,
+		Applications:              &c.Applications,
+		ApplicationVersions:       &c.ApplicationVersions,
+		ApplicationACL:            &c.ApplicationACL,
+		Fleets:                    &c.Fleets,
+		Matchmaker:                &c.Matchmaker,
+		MatchmakerComponents:      &c.MatchmakerComponents,
+		MatchmakerEnvs:            &c.MatchmakerEnvs,
+		MatchmakerReleases:        &c.MatchmakerReleases,
+		MatchmakerManagedReleases: &c.MatchmakerManagedReleases,
+		MatchmakerReleaseConfigs:  &c.MatchmakerReleaseConfigs,
+		Metrics:                   &c.Metrics,
+		Telemetry:                 &c.Telemetry,
+		Locations:                 &c.Locations,
+		IP:                        &c.IP,
+	}
+}
+
+// Returns a successful response holding data, to return from a mocked method.
+func Ok[T any](data T) (*edgegap.Response[T], error) {
+	return &edgegap.Response[T]{Success: true, Data: &data}, nil
+}
+
+// Returns a failed response holding err, to return from a mocked method.
+func Fail[T any](err error) (*edgegap.Response[T], error) {
+	return &edgegap.Response[T]{Error: err}, err
+}
+
+// Returns an iterator yielding items, to return from a mocked Iterate method.
+func Seq[T any](items ...T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func notMocked(method string) error {
+	return fmt.Errorf("%w : %s", ErrNotMocked, method)
+}
+
+func notMockedSeq[T any](method string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		yield(zero, notMocked(method))
+	}
+}
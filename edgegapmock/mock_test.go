@@ -0,0 +1,93 @@
+package edgegapmock_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegapmock"
+)
+
+// readyDeployment is code under test, depending on the narrowest interface it needs.
+func readyDeployment(ctx context.Context, api edgegap.DeploymentsAPI, id string) (bool, error) {
+	res, err := api.Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.Running, nil
+}
+
+func TestMock(t *testing.T) {
+	mock := &edgegapmock.Deployments{
+		GetFunc: func(ctx context.Context, id string) (*edgegap.Response[edgegap.DeploymentInfo], error) {
+			return edgegapmock.Ok(edgegap.DeploymentInfo{RequestID: id, Running: true})
+		},
+	}
+
+	ready, err := readyDeployment(context.Background(), mock, "abc")
+	if err != nil || !ready {
+		t.Fatalf("readyDeployment() = %v, %v, want true", ready, err)
+	}
+
+	calls := mock.Calls()
+
+	if len(calls) != 1 || calls[0].Method != "Get" || calls[0].Args[0] != "abc" {
+		t.Errorf("Calls() = %+v, want a single Get call for abc", calls)
+	}
+}
+
+func TestMockNotMocked(t *testing.T) {
+	client := &edgegapmock.Client{}
+	api := client.API()
+
+	if _, err := api.Sessions.Get(context.Background(), "abc"); !errors.Is(err, edgegapmock.ErrNotMocked) {
+		t.Errorf("Sessions.Get() error = %v, want ErrNotMocked", err)
+	}
+
+	if _, err := api.SessionUsers.List(context.Background(), "abc"); !errors.Is(err, edgegapmock.ErrNotMocked) {
+		t.Errorf("SessionUsers.List() error = %v, want ErrNotMocked", err)
+	}
+
+	for _, err := range api.Deployments.Iterate(context.Background()) {
+		if !errors.Is(err, edgegapmock.ErrNotMocked) {
+			t.Errorf("Deployments.Iterate() error = %v, want ErrNotMocked", err)
+		}
+	}
+
+	if client.Sessions.CallCount("Get") != 1 || client.SessionUsers.CallCount("List") != 1 {
+		t.Errorf("CallCount() = %d and %d, want 1 each", client.Sessions.CallCount("Get"), client.SessionUsers.CallCount("List"))
+	}
+}
+
+func TestMockHelpers(t *testing.T) {
+	mock := &edgegapmock.Fleets{
+		GetFunc: func(ctx context.Context, name string) (*edgegap.Response[edgegap.Fleet], error) {
+			return edgegapmock.Fail[edgegap.Fleet](&edgegap.APIError{StatusCode: 404})
+		},
+		IterateFunc: func(ctx context.Context) iter.Seq2[edgegap.Fleet, error] {
+			return edgegapmock.Seq(edgegap.Fleet{Name: "eu"}, edgegap.Fleet{Name: "us"})
+		},
+	}
+
+	res, err := mock.Get(context.Background(), "eu")
+	if !edgegap.IsNotFound(err) || res.Error != err {
+		t.Errorf("Get() = %+v, %v, want a not found failure", res, err)
+	}
+
+	var names []string
+
+	for fleet, err := range mock.Iterate(context.Background()) {
+		if err != nil {
+			t.Fatalf("Iterate() error = %v", err)
+		}
+
+		names = append(names, fleet.Name)
+	}
+
+	if len(names) != 2 {
+		t.Errorf("Iterate() = %v, want 2 fleets", names)
+	}
+}
@@ -0,0 +1,134 @@
+package edgegapmock
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Sessions is a programmable mock of edgegap.SessionsAPI.
+type Sessions struct {
+	recorder
+
+	CreateFunc     func(context.Context, *edgegap.SessionCreate) (*edgegap.Response[edgegap.SessionCreateRes], error)
+	DeleteFunc     func(context.Context, string) (*edgegap.Response[edgegap.SessionDeleteRes], error)
+	GetFunc        func(context.Context, string) (*edgegap.Response[edgegap.Session], error)
+	ListFunc       func(context.Context) (*edgegap.Response[edgegap.ResponseBody[edgegap.Session]], error)
+	ListPageFunc   func(context.Context, edgegap.PaginationParams) (*edgegap.Response[edgegap.ResponseBody[edgegap.Session]], error)
+	IterateFunc    func(context.Context) iter.Seq2[edgegap.Session, error]
+	BulkDeleteFunc func(context.Context, []edgegap.Filter) (*edgegap.Response[edgegap.SessionBulkDeleteRes], error)
+}
+
+var _ edgegap.SessionsAPI = (*Sessions)(nil)
+
+func (m *Sessions) Create(ctx context.Context, session *edgegap.SessionCreate) (*edgegap.Response[edgegap.SessionCreateRes], error) {
+	m.record("Create", session)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, session)
+}
+
+func (m *Sessions) Delete(ctx context.Context, id string) (*edgegap.Response[edgegap.SessionDeleteRes], error) {
+	m.record("Delete", id)
+
+	if m.DeleteFunc == nil {
+		return nil, notMocked("Delete")
+	}
+
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *Sessions) Get(ctx context.Context, id string) (*edgegap.Response[edgegap.Session], error) {
+	m.record("Get", id)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, id)
+}
+
+func (m *Sessions) List(ctx context.Context) (*edgegap.Response[edgegap.ResponseBody[edgegap.Session]], error) {
+	m.record("List")
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx)
+}
+
+func (m *Sessions) ListPage(ctx context.Context, params edgegap.PaginationParams) (*edgegap.Response[edgegap.ResponseBody[edgegap.Session]], error) {
+	m.record("ListPage", params)
+
+	if m.ListPageFunc == nil {
+		return nil, notMocked("ListPage")
+	}
+
+	return m.ListPageFunc(ctx, params)
+}
+
+func (m *Sessions) Iterate(ctx context.Context) iter.Seq2[edgegap.Session, error] {
+	m.record("Iterate")
+
+	if m.IterateFunc == nil {
+		return notMockedSeq[edgegap.Session]("Iterate")
+	}
+
+	return m.IterateFunc(ctx)
+}
+
+func (m *Sessions) BulkDelete(ctx context.Context, filters []edgegap.Filter) (*edgegap.Response[edgegap.SessionBulkDeleteRes], error) {
+	m.record("BulkDelete", filters)
+
+	if m.BulkDeleteFunc == nil {
+		return nil, notMocked("BulkDelete")
+	}
+
+	return m.BulkDeleteFunc(ctx, filters)
+}
+
+// SessionUsers is a programmable mock of edgegap.SessionUsersAPI.
+type SessionUsers struct {
+	recorder
+
+	AddFunc    func(context.Context, string, []string) (*edgegap.Response[edgegap.SessionUserRes], error)
+	RemoveFunc func(context.Context, string, []string) (*edgegap.Response[edgegap.SessionUserRes], error)
+	ListFunc   func(context.Context, string) (*edgegap.Response[edgegap.SessionUserRes], error)
+}
+
+var _ edgegap.SessionUsersAPI = (*SessionUsers)(nil)
+
+func (m *SessionUsers) Add(ctx context.Context, id string, ips []string) (*edgegap.Response[edgegap.SessionUserRes], error) {
+	m.record("Add", id, ips)
+
+	if m.AddFunc == nil {
+		return nil, notMocked("Add")
+	}
+
+	return m.AddFunc(ctx, id, ips)
+}
+
+func (m *SessionUsers) Remove(ctx context.Context, id string, ips []string) (*edgegap.Response[edgegap.SessionUserRes], error) {
+	m.record("Remove", id, ips)
+
+	if m.RemoveFunc == nil {
+		return nil, notMocked("Remove")
+	}
+
+	return m.RemoveFunc(ctx, id, ips)
+}
+
+func (m *SessionUsers) List(ctx context.Context, id string) (*edgegap.Response[edgegap.SessionUserRes], error) {
+	m.record("List", id)
+
+	if m.ListFunc == nil {
+		return nil, notMocked("List")
+	}
+
+	return m.ListFunc(ctx, id)
+}
@@ -0,0 +1,37 @@
+package edgegapmock
+
+import (
+	"context"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Telemetry is a programmable mock of edgegap.TelemetryAPI.
+type Telemetry struct {
+	recorder
+
+	CreateFunc func(context.Context, edgegap.TelemetryCreate) (*edgegap.Response[edgegap.TelemetryCreateRes], error)
+	GetFunc    func(context.Context, string) (*edgegap.Response[edgegap.Telemetry], error)
+}
+
+var _ edgegap.TelemetryAPI = (*Telemetry)(nil)
+
+func (m *Telemetry) Create(ctx context.Context, payload edgegap.TelemetryCreate) (*edgegap.Response[edgegap.TelemetryCreateRes], error) {
+	m.record("Create", payload)
+
+	if m.CreateFunc == nil {
+		return nil, notMocked("Create")
+	}
+
+	return m.CreateFunc(ctx, payload)
+}
+
+func (m *Telemetry) Get(ctx context.Context, id string) (*edgegap.Response[edgegap.Telemetry], error) {
+	m.record("Get", id)
+
+	if m.GetFunc == nil {
+		return nil, notMocked("Get")
+	}
+
+	return m.GetFunc(ctx, id)
+}
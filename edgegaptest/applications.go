@@ -0,0 +1,381 @@
+package edgegaptest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+type appState struct {
+	app      edgegap.Application
+	versions map[string]*versionState
+	order    []string
+}
+
+type versionState struct {
+	version edgegap.ApplicationVersion
+	acl     map[string]edgegap.ApplicationACL
+	order   []string
+}
+
+// Adds an application and its versions to the fake, replacing any existing one with the same name.
+func (s *Server) AddApplication(app edgegap.Application, versions ...edgegap.ApplicationVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if app.CreateTime == "" {
+		app.CreateTime = now()
+		app.LastUpdate = app.CreateTime
+	}
+
+	state := &appState{app: app, versions: map[string]*versionState{}}
+
+	for _, version := range versions {
+		state.addVersion(version)
+	}
+
+	s.apps[app.Name] = state
+}
+
+func (a *appState) addVersion(version edgegap.ApplicationVersion) {
+	a.versions[version.Name] = &versionState{version: version, acl: map[string]edgegap.ApplicationACL{}}
+	a.order = append(a.order, version.Name)
+}
+
+// latest returns the last version created, nil when there is none.
+func (a *appState) latest() *versionState {
+	if len(a.order) == 0 {
+		return nil
+	}
+
+	return a.versions[a.order[len(a.order)-1]]
+}
+
+func (a *appState) removeVersion(name string) {
+	delete(a.versions, name)
+	a.order = remove(a.order, name)
+}
+
+func remove(items []string, item string) []string {
+	for i, candidate := range items {
+		if candidate == item {
+			return append(items[:i:i], items[i+1:]...)
+		}
+	}
+
+	return items
+}
+
+// lookupVersion returns the application and version of the request path, answering with a 404 when missing.
+// Must be called with mu held.
+func (s *Server) lookupVersion(w http.ResponseWriter, r *http.Request) (*appState, *versionState, bool) {
+	app, ok := s.apps[r.PathValue("app")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", r.PathValue("app")))
+		return nil, nil, false
+	}
+
+	version, ok := app.versions[r.PathValue("version")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Version %s not found", r.PathValue("version")))
+		return nil, nil, false
+	}
+
+	return app, version, true
+}
+
+func (s *Server) registerApplications(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/app", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.ApplicationCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		if payload.Name == "" {
+			writeError(w, http.StatusBadRequest, "The application name is required")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.apps[payload.Name]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("App %s already exists", payload.Name))
+			return
+		}
+
+		app := edgegap.Application{
+			Name:                   payload.Name,
+			IsActive:               payload.IsActive,
+			IsTelemetryAgentActive: payload.IsTelemetryAgentActive,
+			Image:                  payload.Image,
+			CreateTime:             now(),
+		}
+		app.LastUpdate = app.CreateTime
+
+		s.apps[app.Name] = &appState{app: app, versions: map[string]*versionState{}}
+
+		writeJSON(w, http.StatusOK, app)
+	})
+
+	mux.HandleFunc("GET /v1/apps", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		list := edgegap.ApplicationList{Applications: []edgegap.Application{}}
+
+		for _, app := range s.apps {
+			list.Applications = append(list.Applications, app.app)
+		}
+
+		sort.Slice(list.Applications, func(i, j int) bool {
+			return list.Applications[i].Name < list.Applications[j].Name
+		})
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	mux.HandleFunc("GET /v1/app/{app}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, ok := s.apps[r.PathValue("app")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", r.PathValue("app")))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, app.app)
+	})
+
+	mux.HandleFunc("PATCH /v1/app/{app}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.ApplicationCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, ok := s.apps[r.PathValue("app")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", r.PathValue("app")))
+			return
+		}
+
+		if payload.Name != "" && payload.Name != app.app.Name {
+			delete(s.apps, app.app.Name)
+			app.app.Name = payload.Name
+			s.apps[app.app.Name] = app
+		}
+
+		app.app.IsActive = payload.IsActive
+		app.app.IsTelemetryAgentActive = payload.IsTelemetryAgentActive
+		app.app.Image = payload.Image
+		app.app.LastUpdate = now()
+
+		writeJSON(w, http.StatusOK, app.app)
+	})
+
+	mux.HandleFunc("DELETE /v1/app/{app}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.apps[r.PathValue("app")]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", r.PathValue("app")))
+			return
+		}
+
+		delete(s.apps, r.PathValue("app"))
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	mux.HandleFunc("POST /v1/app/{app}/version", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.ApplicationVersion
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, ok := s.apps[r.PathValue("app")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", r.PathValue("app")))
+			return
+		}
+
+		if _, ok := app.versions[payload.Name]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Version %s already exists", payload.Name))
+			return
+		}
+
+		app.addVersion(payload)
+
+		writeJSON(w, http.StatusOK, edgegap.ApplicationVersionCreateResponse{Success: true, Version: payload})
+	})
+
+	mux.HandleFunc("GET /v1/app/{app}/versions", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, ok := s.apps[r.PathValue("app")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", r.PathValue("app")))
+			return
+		}
+
+		list := edgegap.ApplicationVersionList{Versions: []edgegap.ApplicationVersion{}}
+
+		for _, name := range app.order {
+			list.Versions = append(list.Versions, app.versions[name].version)
+		}
+
+		list.TotalCount = len(list.Versions)
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	mux.HandleFunc("GET /v1/app/{app}/version/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, version, ok := s.lookupVersion(w, r); ok {
+			writeJSON(w, http.StatusOK, version.version)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/app/{app}/version/{version}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.ApplicationVersion
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, version, ok := s.lookupVersion(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.Name == "" {
+			payload.Name = version.version.Name
+		}
+
+		if payload.Name != version.version.Name {
+			delete(app.versions, version.version.Name)
+			app.versions[payload.Name] = version
+
+			for i, name := range app.order {
+				if name == version.version.Name {
+					app.order[i] = payload.Name
+				}
+			}
+		}
+
+		version.version = payload
+
+		writeJSON(w, http.StatusOK, edgegap.ApplicationVersionCreateResponse{Success: true, Version: payload})
+	})
+
+	mux.HandleFunc("DELETE /v1/app/{app}/version/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, version, ok := s.lookupVersion(w, r)
+		if !ok {
+			return
+		}
+
+		app.removeVersion(version.version.Name)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	mux.HandleFunc("POST /v1/app/{app}/version/{version}/whitelist", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.ApplicationACL
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		_, version, ok := s.lookupVersion(w, r)
+		if !ok {
+			return
+		}
+
+		payload.ID = s.nextID()
+		version.acl[payload.ID] = payload
+		version.order = append(version.order, payload.ID)
+
+		writeJSON(w, http.StatusOK, edgegap.ApplicationACLCreateResponse{Success: true, WhiteListEntry: payload})
+	})
+
+	mux.HandleFunc("GET /v1/app/{app}/version/{version}/whitelist", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		_, version, ok := s.lookupVersion(w, r)
+		if !ok {
+			return
+		}
+
+		entries := edgegap.ApplicationACLEntries{WhitelistEntries: []edgegap.ApplicationACL{}}
+
+		for _, id := range version.order {
+			entries.WhitelistEntries = append(entries.WhitelistEntries, version.acl[id])
+		}
+
+		writeJSON(w, http.StatusOK, entries)
+	})
+
+	mux.HandleFunc("GET /v1/app/{app}/version/{version}/whitelist/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		_, version, ok := s.lookupVersion(w, r)
+		if !ok {
+			return
+		}
+
+		entry, ok := version.acl[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Entry %s not found", r.PathValue("id")))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, entry)
+	})
+
+	mux.HandleFunc("DELETE /v1/app/{app}/version/{version}/whitelist/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		_, version, ok := s.lookupVersion(w, r)
+		if !ok {
+			return
+		}
+
+		entry, ok := version.acl[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Entry %s not found", r.PathValue("id")))
+			return
+		}
+
+		delete(version.acl, entry.ID)
+		version.order = remove(version.order, entry.ID)
+
+		writeJSON(w, http.StatusOK, edgegap.ApplicationACLCreateResponse{Success: true, WhiteListEntry: entry})
+	})
+}
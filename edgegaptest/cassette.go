@@ -0,0 +1,301 @@
+package edgegaptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Mode selects what a Cassette does with the requests going through it.
+type Mode string
+
+const (
+	ModeReplay      = Mode("replay")      // Answer from the recorded interactions, without reaching the network
+	ModeRecord      = Mode("record")      // Send the requests and record the interactions, written to the file on Close
+	ModePassthrough = Mode("passthrough") // Send the requests without recording them
+)
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches a request.
+var ErrNoInteraction = errors.New("edgegaptest: no recorded interaction matches the request")
+
+// Interaction is a request and its response, as stored in a cassette file.
+type Interaction struct {
+	Request  InteractionRequest  `json:"request"`
+	Response InteractionResponse `json:"response"`
+}
+
+// InteractionRequest is a request stored in a cassette file.
+type InteractionRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`            // Full path of the request, including the API version
+	Query  string `json:"query,omitempty"` // Query, with its parameters sorted
+	Body   string `json:"body,omitempty"`  // Body, with its secrets redacted
+}
+
+// InteractionResponse is a response stored in a cassette file.
+type InteractionResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"` // Headers, with their secrets redacted
+	Body   string            `json:"body,omitempty"`   // Body, with its secrets redacted
+}
+
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Cassette is an http.RoundTripper recording the interactions with the API to a file and replaying them,
+// so tests written against the real API can run offline and deterministically.
+//
+// The Authorization header and the secrets of the bodies (tokens, passwords, hidden environment variables)
+// are scrubbed before being stored. Requests are matched on their method, path, query and scrubbed body,
+// each recorded interaction being replayed once, in the recorded order.
+type Cassette struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// CassetteOption configures a Cassette created with NewCassette.
+type CassetteOption func(*Cassette)
+
+// Sets the transport used to send the requests in record and passthrough modes. Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) CassetteOption {
+	return func(c *Cassette) {
+		c.transport = transport
+	}
+}
+
+// Parses a mode, i.e. read from an environment variable. An empty value is ModeReplay.
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(value)); mode {
+	case "":
+		return ModeReplay, nil
+	case ModeReplay, ModeRecord, ModePassthrough:
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("edgegaptest: unknown cassette mode %q", value)
+}
+
+// Opens the cassette stored at path. In replay mode the file must exist, in record mode it is overwritten on Close.
+func NewCassette(path string, mode Mode, opts ...CassetteOption) (*Cassette, error) {
+	c := &Cassette{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	switch mode {
+	case ModeReplay:
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("edgegaptest: reading cassette : %w", err)
+		}
+
+		var file cassetteFile
+
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return nil, fmt.Errorf("edgegaptest: decoding cassette %s : %w", path, err)
+		}
+
+		c.interactions = file.Interactions
+		c.used = make([]bool, len(file.Interactions))
+	case ModeRecord, ModePassthrough:
+	default:
+		return nil, fmt.Errorf("edgegaptest: unknown cassette mode %q", mode)
+	}
+
+	return c, nil
+}
+
+// Returns an http.Client using the cassette as transport, to give to edgegap.WithHTTPClient.
+func (c *Cassette) HTTPClient() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Returns the mode of the cassette.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Returns the interactions recorded or loaded so far.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	interactions := make([]Interaction, len(c.interactions))
+
+	for i, interaction := range c.interactions {
+		interactions[i] = *interaction
+	}
+
+	return interactions
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	request := InteractionRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  canonicalQuery(req.URL.RawQuery),
+		Body:   scrubBody(body),
+	}
+
+	switch c.mode {
+	case ModeReplay:
+		return c.replay(req, request)
+	case ModeRecord:
+		return c.record(req, request)
+	}
+
+	return c.transport.RoundTrip(req)
+}
+
+func (c *Cassette) replay(req *http.Request, request InteractionRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] || !matchRequest(interaction.Request, request) {
+			continue
+		}
+
+		c.used[i] = true
+
+		header := http.Header{}
+		for key, value := range interaction.Response.Header {
+			header.Set(key, value)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w : %s %s", ErrNoInteraction, request.Method, request.Path)
+}
+
+func (c *Cassette) record(req *http.Request, request InteractionRequest) (*http.Response, error) {
+	res, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, &Interaction{
+		Request: request,
+		Response: InteractionResponse{
+			Status: res.StatusCode,
+			Header: edgegap.RedactHeaders(res.Header),
+			Body:   scrubBody(body),
+		},
+	})
+
+	return res, nil
+}
+
+// Writes the recorded interactions to the cassette file in record mode. It does nothing in the other modes.
+func (c *Cassette) Close() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	raw, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, append(raw, '\n'), 0o644)
+}
+
+func matchRequest(recorded, request InteractionRequest) bool {
+	return recorded.Method == request.Method &&
+		recorded.Path == request.Path &&
+		recorded.Query == request.Query &&
+		recorded.Body == request.Body
+}
+
+// canonicalQuery sorts the query parameters, so the order they are written in does not matter.
+func canonicalQuery(raw string) string {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+
+	return values.Encode()
+}
+
+// scrubBody redacts the secrets of a JSON body, re-encoding it with sorted keys. Other bodies are kept as is.
+func scrubBody(body []byte) string {
+	var value interface{}
+
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	scrubbed, err := json.Marshal(edgegap.RedactBody(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(scrubbed)
+}
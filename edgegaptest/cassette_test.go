@@ -0,0 +1,144 @@
+package edgegaptest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+// sessionFlow creates a deployment with a hidden secret, waits for it to be ready and links a session to it.
+func sessionFlow(t *testing.T, client *edgegap.EdgegapClient) (string, string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	created, err := client.DeploymentCreate(ctx, &edgegap.DeployementCreatePayload{
+		AppName:      "game",
+		VersionName:  "v1",
+		EnvVariables: []edgegap.EnvVariabls{{Key: "API_KEY", Value: "s3cr3t", IsHidden: true}},
+	})
+	if err != nil {
+		t.Fatalf("DeploymentCreate() error = %v", err)
+	}
+
+	for {
+		status, err := client.DeploymentGetStatus(ctx, created.Data.RequestID)
+		if err != nil {
+			t.Fatalf("DeploymentGetStatus() error = %v", err)
+		}
+
+		if status.Data.Running {
+			break
+		}
+	}
+
+	session, err := client.SessionCreate(ctx, &edgegap.SessionCreate{
+		App:                 "game",
+		IPList:              []string{"198.51.100.1"},
+		DeploymentRequestID: created.Data.RequestID,
+	})
+	if err != nil {
+		t.Fatalf("SessionCreate() error = %v", err)
+	}
+
+	return created.Data.RequestID, session.Data.SessionID
+}
+
+func TestCassetteRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "session_flow.json")
+
+	server := edgegaptest.NewServer()
+	server.AddApplication(edgegap.Application{Name: "game"}, edgegap.ApplicationVersion{Name: "v1"})
+
+	recorder, err := edgegaptest.NewCassette(path, edgegaptest.ModeRecord)
+	if err != nil {
+		t.Fatalf("NewCassette() error = %v", err)
+	}
+
+	recordedID, recordedSession := sessionFlow(t, server.Client(edgegap.WithHTTPClient(recorder.HTTPClient())))
+
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	server.Close()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette : %v", err)
+	}
+
+	for _, secret := range []string{"s3cr3t", edgegaptest.DefaultToken} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	player, err := edgegaptest.NewCassette(path, edgegaptest.ModeReplay)
+	if err != nil {
+		t.Fatalf("NewCassette() error = %v", err)
+	}
+
+	client := edgegap.NewEdgegapClient("token replay", edgegap.WithBaseURL("http://edgegap.invalid"), edgegap.WithHTTPClient(player.HTTPClient()))
+
+	id, session := sessionFlow(t, client)
+
+	if id != recordedID || session != recordedSession {
+		t.Errorf("replayed ids = %s, %s, want %s, %s", id, session, recordedID, recordedSession)
+	}
+
+	if _, err := client.SessionGet(context.Background(), session); !errors.Is(err, edgegaptest.ErrNoInteraction) {
+		t.Errorf("SessionGet() error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestCassetteReplayMatchesBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip.json")
+
+	server := edgegaptest.NewServer()
+	defer server.Close()
+
+	recorder, _ := edgegaptest.NewCassette(path, edgegaptest.ModeRecord)
+	client := server.Client(edgegap.WithHTTPClient(recorder.HTTPClient()))
+
+	client.IPGetInfoBulk(context.Background(), edgegap.IPBulkInfoPayload{Addresses: []string{"198.51.100.1"}})
+
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	player, _ := edgegaptest.NewCassette(path, edgegaptest.ModeReplay)
+	client = server.Client(edgegap.WithHTTPClient(player.HTTPClient()))
+
+	if _, err := client.IPGetInfoBulk(context.Background(), edgegap.IPBulkInfoPayload{Addresses: []string{"198.51.100.2"}}); !errors.Is(err, edgegaptest.ErrNoInteraction) {
+		t.Errorf("IPGetInfoBulk() with another body error = %v, want ErrNoInteraction", err)
+	}
+
+	if _, err := client.IPGetInfoBulk(context.Background(), edgegap.IPBulkInfoPayload{Addresses: []string{"198.51.100.1"}}); err != nil {
+		t.Errorf("IPGetInfoBulk() error = %v", err)
+	}
+
+	if len(server.Requests()) != 1 {
+		t.Errorf("%d requests reached the server, want only the recorded one", len(server.Requests()))
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if mode, err := edgegaptest.ParseMode(""); err != nil || mode != edgegaptest.ModeReplay {
+		t.Errorf("ParseMode(\"\") = %q, %v, want replay", mode, err)
+	}
+
+	if mode, err := edgegaptest.ParseMode("Record"); err != nil || mode != edgegaptest.ModeRecord {
+		t.Errorf("ParseMode(\"Record\") = %q, %v, want record", mode, err)
+	}
+
+	if _, err := edgegaptest.ParseMode("rewind"); err == nil {
+		t.Error("ParseMode(\"rewind\") error = nil, want an error")
+	}
+}
@@ -0,0 +1,580 @@
+package edgegaptest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+type deploymentState struct {
+	info     edgegap.DeploymentInfo
+	location edgegap.LocationInfo
+	polls    int
+	pinned   bool // If the status was set by the test and must not move on polls
+	joinable bool
+	logs     edgegap.DeploymentContainerLogs
+	crash    *edgegap.ContainerCrashData // Crash repeated on every fetch of the logs, see CrashLoop
+	stopping bool                        // If a stop was requested, the deployment is terminated on the next status poll
+	token    string                      // Token to stop the deployment and get its context from inside its container
+}
+
+// deploymentStopResponse is the body answered when a deployment is stopped.
+type deploymentStopResponse struct {
+	Message           string                 `json:"message"`
+	DeploymentSummary edgegap.DeploymentInfo `json:"deployment_summary"`
+}
+
+// Adds a deployment to the fake and returns its request ID, generated when info.RequestID is empty.
+// The deployment keeps info.CurrentStatus, or is ready when it is empty.
+func (s *Server) AddDeployment(info edgegap.DeploymentInfo) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if info.RequestID == "" {
+		info.RequestID = s.nextID()
+	}
+
+	if info.CurrentStatus == "" {
+		info.CurrentStatus = StatusReady
+	}
+
+	info.Running = info.CurrentStatus == StatusReady
+	info.Error = info.CurrentStatus == StatusError
+
+	s.addDeployment(&deploymentState{info: info, pinned: true, joinable: true})
+
+	return info.RequestID
+}
+
+// Returns the current state of a deployment, without counting as a status poll.
+func (s *Server) Deployment(requestID string) (edgegap.DeploymentInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment, ok := s.deployments[requestID]
+	if !ok {
+		return edgegap.DeploymentInfo{}, false
+	}
+
+	return deployment.info, true
+}
+
+// Forces the status of a deployment, which then stops moving as it is polled.
+// StatusError flags the deployment in error and StatusTerminated stops it.
+func (s *Server) SetDeploymentStatus(requestID string, status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment, ok := s.deployments[requestID]
+	if !ok {
+		return false
+	}
+
+	deployment.pinned = true
+
+	if status == StatusTerminated {
+		s.terminate(deployment)
+		return true
+	}
+
+	deployment.setStatus(status)
+
+	return true
+}
+
+// Sets the container logs returned for a deployment.
+func (s *Server) SetContainerLogs(requestID string, logs edgegap.DeploymentContainerLogs) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment, ok := s.deployments[requestID]
+	if !ok {
+		return false
+	}
+
+	deployment.logs = logs
+
+	return true
+}
+
+// Makes the container of a deployment crash loop : every fetch of its logs reports crash once more, with one more restart.
+func (s *Server) CrashLoop(requestID string, crash edgegap.ContainerCrashData) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment, ok := s.deployments[requestID]
+	if !ok {
+		return false
+	}
+
+	deployment.crash = &crash
+
+	return true
+}
+
+// Returns the delete URL and token injected in a deployment (ARBITRIUM_DELETE_URL and ARBITRIUM_DELETE_TOKEN),
+// to stop it from inside its container.
+func (s *Server) SelfStopCredentials(requestID string) (string, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment, ok := s.deployments[requestID]
+	if !ok {
+		return "", "", false
+	}
+
+	return fmt.Sprintf("%s/%s/self/stop/%s", s.URL, edgegap.VersionOne, requestID), deployment.token, true
+}
+
+// Must be called with mu held.
+func (s *Server) addDeployment(deployment *deploymentState) {
+	deployment.token = fmt.Sprintf("%x", sha256.Sum256([]byte(deployment.info.RequestID)))[:32]
+	s.deployments[deployment.info.RequestID] = deployment
+	s.deploymentIDs = append(s.deploymentIDs, deployment.info.RequestID)
+}
+
+// terminate stops a deployment and removes it from the active ones. Must be called with mu held.
+func (s *Server) terminate(deployment *deploymentState) {
+	if deployment.info.CurrentStatus == StatusTerminated {
+		return
+	}
+
+	deployment.setStatus(StatusTerminated)
+	deployment.info.RemovalTime = now()
+	s.deploymentIDs = remove(s.deploymentIDs, deployment.info.RequestID)
+}
+
+// stop queues the stop of a deployment and returns the body answered to the stop request. Must be called with mu held.
+func (s *Server) stop(deployment *deploymentState) deploymentStopResponse {
+	deployment.stopping = true
+
+	return deploymentStopResponse{
+		Message:           fmt.Sprintf("Deployment %s will be deleted", deployment.info.RequestID),
+		DeploymentSummary: deployment.info,
+	}
+}
+
+func (d *deploymentState) setStatus(status string) {
+	d.info.LastStatus = d.info.CurrentStatus
+	d.info.CurrentStatus = status
+	d.info.Running = status == StatusReady
+	d.info.Error = status == StatusError
+
+	if d.info.Running && d.info.StartTime == "" {
+		d.info.StartTime = now()
+	}
+}
+
+// poll moves the deployment forward as its status is retrieved : Seeking, Deploying then Ready.
+func (d *deploymentState) poll(readyAfter int) {
+	if d.pinned || d.info.CurrentStatus == StatusTerminated {
+		return
+	}
+
+	d.polls++
+
+	switch {
+	case d.polls >= readyAfter:
+		d.setStatus(StatusReady)
+	case d.polls > 1:
+		d.setStatus(StatusDeploying)
+	}
+}
+
+func (d *deploymentState) summary() edgegap.Deployment {
+	return edgegap.Deployment{
+		RequestID:           d.info.RequestID,
+		FQDN:                d.info.FDQN,
+		StartTime:           d.info.StartTime,
+		Ready:               d.info.Running,
+		PublicIP:            d.info.PublicIP,
+		Ports:               d.info.Ports,
+		Tags:                d.info.Tags,
+		Sockets:             strconv.Itoa(d.info.Sockets),
+		SocketsUsage:        strconv.Itoa(d.info.SocketsUsage),
+		IsJoinableBySession: d.joinable,
+	}
+}
+
+// filterValues returns the values of a deployment for a filter field.
+func (d *deploymentState) filterValues(field edgegap.EField) []string {
+	switch field {
+	case edgegap.ERequestID:
+		return []string{d.info.RequestID}
+	case edgegap.EDeploymentTag:
+		return d.info.Tags
+	case edgegap.ECity:
+		return []string{d.location.City}
+	case edgegap.ECountry:
+		return []string{d.location.Country}
+	case edgegap.EContinent:
+		return []string{d.location.Continent}
+	case edgegap.EAdminDivision:
+		return []string{d.location.AdminiDivision}
+	case edgegap.ELocationTags:
+		return d.location.Tags
+	case edgegap.ESessionID:
+		var ids []string
+
+		for _, session := range d.info.Sessions {
+			ids = append(ids, session.SessionID)
+		}
+
+		return ids
+	}
+
+	return nil
+}
+
+// Returns the ARBITRIUM_* environment variables Edgegap injects in the container of a deployment,
+// with the delete and context URLs pointing to the fake.
+func (s *Server) Env(requestID string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment, ok := s.deployments[requestID]
+	if !ok {
+		return nil, false
+	}
+
+	ports, _ := json.Marshal(map[string]interface{}{"ports": deployment.info.Ports})
+
+	location, _ := json.Marshal(map[string]interface{}{
+		"city":                    deployment.location.City,
+		"country":                 deployment.location.Country,
+		"continent":               deployment.location.Continent,
+		"administrative_division": deployment.location.AdminiDivision,
+		"timezone":                deployment.location.Timezone,
+		"latitude":                deployment.info.Location.Latitude,
+		"longitude":               deployment.info.Location.Longitude,
+	})
+
+	return map[string]string{
+		"ARBITRIUM_REQUEST_ID":          requestID,
+		"ARBITRIUM_PUBLIC_IP":           deployment.info.PublicIP,
+		"ARBITRIUM_FQDN":                deployment.info.FDQN,
+		"ARBITRIUM_DEPLOYMENT_TAGS":     strings.Join(deployment.info.Tags, ","),
+		"ARBITRIUM_PORTS_MAPPING":       string(ports),
+		"ARBITRIUM_DEPLOYMENT_LOCATION": string(location),
+		"ARBITRIUM_DELETE_URL":          fmt.Sprintf("%s/%s/self/stop/%s", s.URL, edgegap.VersionOne, requestID),
+		"ARBITRIUM_DELETE_TOKEN":        deployment.token,
+		"ARBITRIUM_CONTEXT_URL":         fmt.Sprintf("%s/%s/self/context/%s", s.URL, edgegap.VersionOne, requestID),
+		"ARBITRIUM_CONTEXT_TOKEN":       deployment.token,
+	}, true
+}
+
+// createDeployment starts a deployment of an application version. Must be called with mu held.
+func (s *Server) createDeployment(appName, versionName string, users int, tags []string) (*deploymentState, int, string) {
+	app, ok := s.apps[appName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Sprintf("App %s not found", appName)
+	}
+
+	version := app.latest()
+
+	if versionName != "" {
+		version = app.versions[versionName]
+	}
+
+	if version == nil {
+		return nil, http.StatusNotFound, fmt.Sprintf("Version %s not found for app %s", versionName, appName)
+	}
+
+	id := s.nextID()
+	ports := map[string]edgegap.PortDetails{}
+
+	for i, port := range version.version.Ports {
+		name := port.Name
+		if name == "" {
+			name = fmt.Sprintf("port%d", port.Port)
+		}
+
+		ports[name] = edgegap.PortDetails{
+			Name:       name,
+			Internal:   port.Port,
+			External:   31000 + s.ids*10 + i,
+			Protocol:   string(port.Protocol),
+			TLSUpgrade: port.TLSUpgrade,
+		}
+	}
+
+	var location edgegap.LocationInfo
+	if len(s.locations) > 0 {
+		location = s.locations[s.ids%len(s.locations)]
+	}
+
+	deployment := &deploymentState{
+		info: edgegap.DeploymentInfo{
+			RequestID:     id,
+			FDQN:          id + ".pr.edgegap.net",
+			AppName:       appName,
+			AppVersion:    version.version.Name,
+			CurrentStatus: StatusSeeking,
+			PublicIP:      fmt.Sprintf("203.0.113.%d", s.ids%254+1),
+			Ports:         ports,
+			Location:      edgegap.Location{Latitude: location.Latitude, Longitude: location.Longitude},
+			Tags:          tags,
+			Sockets:       version.version.SessionConfig.Sockets,
+			SocketsUsage:  users,
+			MaxDuration:   version.version.MaxDuration,
+			Sessions:      []edgegap.DeploymentSession{},
+		},
+		location: location,
+		joinable: true,
+	}
+
+	if s.readyAfter <= 0 {
+		deployment.setStatus(StatusReady)
+	}
+
+	s.addDeployment(deployment)
+
+	return deployment, http.StatusOK, ""
+}
+
+func (s *Server) registerDeployments(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/deploy", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.DeployementCreatePayload
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		users := len(payload.IpList) + len(payload.GeoIPList)
+
+		deployment, status, message := s.createDeployment(payload.AppName, payload.VersionName, users, payload.Tags)
+		if deployment == nil {
+			writeError(w, status, message)
+			return
+		}
+
+		deployment.info.Command = payload.Command
+		deployment.info.Arguments = payload.Arguments
+
+		writeJSON(w, http.StatusOK, edgegap.DeploymentCreateResponse{
+			RequestID:        deployment.info.RequestID,
+			RequestDNS:       deployment.info.FDQN,
+			RequestApp:       deployment.info.AppName,
+			RequestVersion:   deployment.info.AppVersion,
+			RequestUserCount: users,
+			City:             deployment.location.City,
+			Country:          deployment.location.Country,
+			Continent:        deployment.location.Continent,
+			AdminDivision:    deployment.location.AdminiDivision,
+			Tags:             deployment.info.Tags,
+		})
+	})
+
+	mux.HandleFunc("GET /v1/status/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployment, ok := s.deployments[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		if deployment.stopping {
+			s.terminate(deployment)
+		}
+
+		deployment.poll(s.readyAfter)
+
+		writeJSON(w, http.StatusOK, deployment.info)
+	})
+
+	mux.HandleFunc("DELETE /v1/stop/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployment, ok := s.deployments[r.PathValue("id")]
+		if !ok || deployment.info.CurrentStatus == StatusTerminated {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.stop(deployment))
+	})
+
+	// Authenticated with the token of the deployment instead of the one of the fake, see SelfStopCredentials.
+	mux.HandleFunc("DELETE /v1/self/stop/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployment, ok := s.deployments[r.PathValue("id")]
+		if !ok || deployment.info.CurrentStatus == StatusTerminated {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		if r.Header.Get("Authorization") != deployment.token {
+			writeError(w, http.StatusUnauthorized, "Invalid delete token")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.stop(deployment))
+	})
+
+	// Authenticated with the token of the deployment, like the self stop endpoint. See Env.
+	mux.HandleFunc("GET /v1/self/context/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployment, ok := s.deployments[r.PathValue("id")]
+		if !ok || deployment.info.CurrentStatus == StatusTerminated {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		if r.Header.Get("Authorization") != deployment.token {
+			writeError(w, http.StatusUnauthorized, "Invalid context token")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, deployment.info)
+	})
+
+	mux.HandleFunc("GET /v1/deploy/{id}/container-logs", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployment, ok := s.deployments[r.PathValue("id")]
+		if !ok || deployment.info.CurrentStatus == StatusTerminated {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		if deployment.crash != nil {
+			deployment.crash.RestartCount++
+			deployment.logs.CrashData = append(deployment.logs.CrashData, *deployment.crash)
+		}
+
+		writeJSON(w, http.StatusOK, deployment.logs)
+	})
+
+	mux.HandleFunc("GET /v1/deployments", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployments := make([]edgegap.Deployment, 0, len(s.deploymentIDs))
+
+		for _, id := range s.deploymentIDs {
+			deployments = append(deployments, s.deployments[id].summary())
+		}
+
+		data, pagination := page(r, deployments)
+
+		writeJSON(w, http.StatusOK, edgegap.ResponseBody[edgegap.Deployment]{
+			Count:      len(deployments),
+			Data:       data,
+			Success:    true,
+			Pagination: pagination,
+		})
+	})
+
+	updateProperties := func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.DeploymentUpdateResponse
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployment, ok := s.deployments[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		deployment.joinable = payload.IsJoinableBySession
+
+		writeJSON(w, http.StatusOK, edgegap.DeploymentUpdateResponse{IsJoinableBySession: deployment.joinable})
+	}
+
+	mux.HandleFunc("PATCH /v1/deployments/{id}", updateProperties)
+	mux.HandleFunc("POST /v1/deployments/{id}", updateProperties)
+
+	mux.HandleFunc("POST /v1/deployments:available", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.DeploymentAvailableSocketPayload
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		deployments := []edgegap.Deployment{}
+
+		for _, id := range s.deploymentIDs {
+			deployment := s.deployments[id]
+
+			if deployment.info.AppName != payload.AppName || deployment.info.AppVersion != payload.AppVersion {
+				continue
+			}
+
+			available := deployment.info.Sockets - deployment.info.SocketsUsage
+
+			if deployment.info.Running && deployment.joinable && available > 0 && available >= payload.MinimumSockets {
+				deployments = append(deployments, deployment.summary())
+			}
+		}
+
+		writeJSON(w, http.StatusOK, edgegap.ResponseBody[edgegap.Deployment]{
+			Count:   len(deployments),
+			Data:    deployments,
+			Success: true,
+		})
+	})
+
+	bulkStop := func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Filters []edgegap.Filter `json:"filters"`
+		}
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		processable := []string{}
+
+		var matching []*deploymentState
+
+		for _, id := range s.deploymentIDs {
+			deployment := s.deployments[id]
+
+			ok, err := matchFilters(payload.Filters, deployment.filterValues)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if ok && !deployment.stopping {
+				matching = append(matching, deployment)
+			}
+		}
+
+		for _, deployment := range matching {
+			processable = append(processable, deployment.info.RequestID)
+			s.stop(deployment)
+		}
+
+		writeJSON(w, http.StatusOK, edgegap.DeploymentBulkDelete{RequestIDs: processable})
+	}
+
+	mux.HandleFunc("POST /v1/deployments/bulk-stop", bulkStop)
+}
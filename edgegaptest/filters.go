@@ -0,0 +1,13 @@
+package edgegaptest
+
+import (
+	"github.com/kisshan13/go-edgegap"
+)
+
+// matchFilters reports if every filter matches the values returned by values for its field, with the semantics of
+// edgegap.MatchFilters. An empty filter list matches everything.
+func matchFilters(filters []edgegap.Filter, values func(field edgegap.EField) []string) (bool, error) {
+	return edgegap.MatchFilters(filters, edgegap.FilterFunc(func(field edgegap.EField) ([]string, bool) {
+		return values(field), true
+	}))
+}
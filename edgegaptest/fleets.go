@@ -0,0 +1,174 @@
+package edgegaptest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+type fleetState struct {
+	fleet edgegap.Fleet
+	links map[string]edgegap.FleetApplication
+}
+
+func (s *Server) lookupFleet(w http.ResponseWriter, r *http.Request) (*fleetState, bool) {
+	fleet, ok := s.fleets[r.PathValue("fleet")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Fleet %s not found", r.PathValue("fleet")))
+		return nil, false
+	}
+
+	return fleet, true
+}
+
+func (s *Server) registerFleets(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/fleet", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.FleetCreatePayload
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.fleets[payload.Name]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Fleet %s already exists", payload.Name))
+			return
+		}
+
+		fleet := edgegap.Fleet{
+			Name:       payload.Name,
+			Enabled:    payload.Enabled,
+			CreateTime: now(),
+		}
+		fleet.LastUpdated = fleet.CreateTime
+
+		s.fleets[fleet.Name] = &fleetState{fleet: fleet, links: map[string]edgegap.FleetApplication{}}
+		s.fleetNames = append(s.fleetNames, fleet.Name)
+
+		writeJSON(w, http.StatusOK, fleet)
+	})
+
+	mux.HandleFunc("GET /v1/fleets", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		fleets := make([]edgegap.Fleet, 0, len(s.fleetNames))
+
+		for _, name := range s.fleetNames {
+			fleets = append(fleets, s.fleets[name].fleet)
+		}
+
+		data, pagination := page(r, fleets)
+
+		writeJSON(w, http.StatusOK, edgegap.FleetList{Fleets: data, Pagination: pagination})
+	})
+
+	mux.HandleFunc("GET /v1/fleet/{fleet}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if fleet, ok := s.lookupFleet(w, r); ok {
+			writeJSON(w, http.StatusOK, fleet.fleet)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/fleet/{fleet}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.FleetCreatePayload
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		fleet, ok := s.lookupFleet(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.Name != "" && payload.Name != fleet.fleet.Name {
+			delete(s.fleets, fleet.fleet.Name)
+
+			for i, name := range s.fleetNames {
+				if name == fleet.fleet.Name {
+					s.fleetNames[i] = payload.Name
+				}
+			}
+
+			fleet.fleet.Name = payload.Name
+			s.fleets[payload.Name] = fleet
+		}
+
+		fleet.fleet.Enabled = payload.Enabled
+		fleet.fleet.LastUpdated = now()
+
+		writeJSON(w, http.StatusOK, fleet.fleet)
+	})
+
+	mux.HandleFunc("DELETE /v1/fleet/{fleet}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		fleet, ok := s.lookupFleet(w, r)
+		if !ok {
+			return
+		}
+
+		delete(s.fleets, fleet.fleet.Name)
+		s.fleetNames = remove(s.fleetNames, fleet.fleet.Name)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	mux.HandleFunc("PUT /v1/fleet/{fleet}/app/{app}/version/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		fleet, ok := s.lookupFleet(w, r)
+		if !ok {
+			return
+		}
+
+		if _, _, ok := s.lookupVersion(w, r); !ok {
+			return
+		}
+
+		link := edgegap.FleetApplication{
+			Name:        fleet.fleet.Name,
+			AppName:     r.PathValue("app"),
+			AppVersion:  r.PathValue("version"),
+			Enabled:     fleet.fleet.Enabled,
+			CreateTime:  now(),
+			LastUpdated: now(),
+		}
+
+		fleet.links[link.AppName+"/"+link.AppVersion] = link
+
+		writeJSON(w, http.StatusOK, link)
+	})
+
+	mux.HandleFunc("DELETE /v1/fleet/{fleet}/app/{app}/version/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		fleet, ok := s.lookupFleet(w, r)
+		if !ok {
+			return
+		}
+
+		key := r.PathValue("app") + "/" + r.PathValue("version")
+
+		if _, ok := fleet.links[key]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Version %s is not linked to fleet %s", key, fleet.fleet.Name))
+			return
+		}
+
+		delete(fleet.links, key)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+}
@@ -0,0 +1,656 @@
+package edgegaptest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+type matchmakerState struct {
+	matchmaker edgegap.Matchmaker
+	releases   map[string]edgegap.MatchmakerRelease
+	managed    map[string]edgegap.MatchmakerManagedRelease
+}
+
+type componentState struct {
+	component edgegap.MatchmakerComponent
+	envs      map[string]edgegap.MatchmakerEnvRes
+}
+
+// sortedValues returns the values of m ordered by key.
+func sortedValues[T any](m map[string]T) []T {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	values := make([]T, 0, len(keys))
+
+	for _, key := range keys {
+		values = append(values, m[key])
+	}
+
+	return values
+}
+
+func (s *Server) lookupMatchmaker(w http.ResponseWriter, r *http.Request) (*matchmakerState, bool) {
+	matchmaker, ok := s.matchmakers[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Matchmaker %s not found", r.PathValue("name")))
+		return nil, false
+	}
+
+	return matchmaker, true
+}
+
+func (s *Server) lookupComponent(w http.ResponseWriter, r *http.Request) (*componentState, bool) {
+	component, ok := s.components[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Component %s not found", r.PathValue("name")))
+		return nil, false
+	}
+
+	return component, true
+}
+
+func (s *Server) registerMatchmaker(mux *http.ServeMux) {
+	s.registerMatchmakerComponents(mux)
+	s.registerMatchmakerReleases(mux)
+
+	mux.HandleFunc("POST /v1/aom/matchmaker", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Name string `json:"name"`
+		}
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.matchmakers[payload.Name]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Matchmaker %s already exists", payload.Name))
+			return
+		}
+
+		matchmaker := &matchmakerState{
+			matchmaker: edgegap.Matchmaker{
+				Name:      payload.Name,
+				URL:       fmt.Sprintf("https://%s.mm.edgegap.net", payload.Name),
+				CreatedAt: now(),
+				UpdatedAt: now(),
+			},
+			releases: map[string]edgegap.MatchmakerRelease{},
+			managed:  map[string]edgegap.MatchmakerManagedRelease{},
+		}
+
+		s.matchmakers[payload.Name] = matchmaker
+
+		writeJSON(w, http.StatusOK, matchmaker.matchmaker)
+	})
+
+	mux.HandleFunc("GET /v1/aom/matchmakers", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		list := edgegap.MatchmakerListRes{Data: []edgegap.Matchmaker{}}
+
+		for _, matchmaker := range sortedValues(s.matchmakers) {
+			list.Data = append(list.Data, matchmaker.matchmaker)
+		}
+
+		list.Count = len(list.Data)
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	mux.HandleFunc("GET /v1/aom/matchmaker/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if matchmaker, ok := s.lookupMatchmaker(w, r); ok {
+			writeJSON(w, http.StatusOK, matchmaker.matchmaker)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/aom/matchmaker/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Name string `json:"name"`
+		}
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		matchmaker, ok := s.lookupMatchmaker(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.Name != "" && payload.Name != matchmaker.matchmaker.Name {
+			delete(s.matchmakers, matchmaker.matchmaker.Name)
+			matchmaker.matchmaker.Name = payload.Name
+			s.matchmakers[payload.Name] = matchmaker
+		}
+
+		matchmaker.matchmaker.UpdatedAt = now()
+
+		writeJSON(w, http.StatusOK, matchmaker.matchmaker)
+	})
+
+	mux.HandleFunc("DELETE /v1/aom/matchmaker/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if matchmaker, ok := s.lookupMatchmaker(w, r); ok {
+			delete(s.matchmakers, matchmaker.matchmaker.Name)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	})
+}
+
+func (s *Server) registerMatchmakerComponents(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/aom/component", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerComponentCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.components[payload.Name]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Component %s already exists", payload.Name))
+			return
+		}
+
+		component := &componentState{
+			component: edgegap.MatchmakerComponent{
+				Name:        payload.Name,
+				Repo:        payload.Repo,
+				Image:       payload.Image,
+				Tag:         payload.Tag,
+				Credentials: payload.Credentials,
+				CreatedAt:   now(),
+				UpdatedAt:   now(),
+			},
+			envs: map[string]edgegap.MatchmakerEnvRes{},
+		}
+
+		s.components[payload.Name] = component
+
+		writeJSON(w, http.StatusOK, component.component)
+	})
+
+	mux.HandleFunc("GET /v1/aom/components", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		list := edgegap.MatchmakerComponentListRes{Data: []edgegap.MatchmakerComponent{}}
+
+		for _, component := range sortedValues(s.components) {
+			list.Data = append(list.Data, component.component)
+		}
+
+		list.Count = len(list.Data)
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	mux.HandleFunc("GET /v1/aom/component/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if component, ok := s.lookupComponent(w, r); ok {
+			writeJSON(w, http.StatusOK, component.component)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/aom/component/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerComponentCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		component, ok := s.lookupComponent(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.Name != "" && payload.Name != component.component.Name {
+			delete(s.components, component.component.Name)
+			component.component.Name = payload.Name
+			s.components[payload.Name] = component
+		}
+
+		component.component.Repo = payload.Repo
+		component.component.Image = payload.Image
+		component.component.Tag = payload.Tag
+		component.component.Credentials = payload.Credentials
+		component.component.UpdatedAt = now()
+
+		writeJSON(w, http.StatusOK, component.component)
+	})
+
+	mux.HandleFunc("DELETE /v1/aom/component/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if component, ok := s.lookupComponent(w, r); ok {
+			delete(s.components, component.component.Name)
+			writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Component %s deleted", component.component.Name)})
+		}
+	})
+
+	mux.HandleFunc("POST /v1/aom/component/{name}/env", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerEnv
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		component, ok := s.lookupComponent(w, r)
+		if !ok {
+			return
+		}
+
+		if _, ok := component.envs[payload.Key]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Env %s already exists", payload.Key))
+			return
+		}
+
+		env := edgegap.MatchmakerEnvRes{Key: payload.Key, Value: payload.Value, CreatedAt: now(), UpdatedAt: now()}
+		component.envs[payload.Key] = env
+
+		writeJSON(w, http.StatusOK, env)
+	})
+
+	mux.HandleFunc("GET /v1/aom/component/{name}/envs", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		component, ok := s.lookupComponent(w, r)
+		if !ok {
+			return
+		}
+
+		list := edgegap.MatchmakerEnvListRes{Data: sortedValues(component.envs)}
+		list.Count = len(list.Data)
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	lookupEnv := func(w http.ResponseWriter, r *http.Request) (*componentState, edgegap.MatchmakerEnvRes, bool) {
+		component, ok := s.lookupComponent(w, r)
+		if !ok {
+			return nil, edgegap.MatchmakerEnvRes{}, false
+		}
+
+		env, ok := component.envs[r.PathValue("key")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Env %s not found", r.PathValue("key")))
+			return nil, edgegap.MatchmakerEnvRes{}, false
+		}
+
+		return component, env, true
+	}
+
+	mux.HandleFunc("GET /v1/aom/component/{name}/env/{key}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, env, ok := lookupEnv(w, r); ok {
+			writeJSON(w, http.StatusOK, env)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/aom/component/{name}/env/{key}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerEnv
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		component, env, ok := lookupEnv(w, r)
+		if !ok {
+			return
+		}
+
+		env.Value = payload.Value
+		env.UpdatedAt = now()
+		component.envs[env.Key] = env
+
+		writeJSON(w, http.StatusOK, env)
+	})
+
+	mux.HandleFunc("DELETE /v1/aom/component/{name}/env/{key}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if component, env, ok := lookupEnv(w, r); ok {
+			delete(component.envs, env.Key)
+			writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Env %s deleted", env.Key)})
+		}
+	})
+}
+
+func (s *Server) registerMatchmakerReleases(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/aom/matchmaker/{name}/release", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerReleaseCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		matchmaker, ok := s.lookupMatchmaker(w, r)
+		if !ok {
+			return
+		}
+
+		if _, ok := matchmaker.releases[payload.Version]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Release %s already exists", payload.Version))
+			return
+		}
+
+		for _, name := range []string{payload.FrontendComponentName, payload.DirectorComponentName, payload.MatchFunctionComponentName} {
+			if _, ok := s.components[name]; name != "" && !ok {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("Component %s not found", name))
+				return
+			}
+		}
+
+		release := edgegap.MatchmakerRelease{
+			Version:                    payload.Version,
+			FrontendComponentName:      payload.FrontendComponentName,
+			DirectorComponentName:      payload.DirectorComponentName,
+			MatchFunctionComponentName: payload.MatchFunctionComponentName,
+			CreatedAt:                  now(),
+			UpdatedAt:                  now(),
+		}
+
+		matchmaker.releases[release.Version] = release
+
+		writeJSON(w, http.StatusOK, release)
+	})
+
+	mux.HandleFunc("GET /v1/aom/matchmaker/{name}/release", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		matchmaker, ok := s.lookupMatchmaker(w, r)
+		if !ok {
+			return
+		}
+
+		list := edgegap.MatchmakerReleaseListRes{Data: sortedValues(matchmaker.releases)}
+		list.Count = len(list.Data)
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	lookupRelease := func(w http.ResponseWriter, r *http.Request) (*matchmakerState, edgegap.MatchmakerRelease, bool) {
+		matchmaker, ok := s.lookupMatchmaker(w, r)
+		if !ok {
+			return nil, edgegap.MatchmakerRelease{}, false
+		}
+
+		release, ok := matchmaker.releases[r.PathValue("version")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Release %s not found", r.PathValue("version")))
+			return nil, edgegap.MatchmakerRelease{}, false
+		}
+
+		return matchmaker, release, true
+	}
+
+	mux.HandleFunc("GET /v1/aom/matchmaker/{name}/release/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, release, ok := lookupRelease(w, r); ok {
+			writeJSON(w, http.StatusOK, release)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/aom/matchmaker/{name}/release/{version}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerReleaseCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		matchmaker, release, ok := lookupRelease(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.FrontendComponentName != "" {
+			release.FrontendComponentName = payload.FrontendComponentName
+		}
+
+		if payload.DirectorComponentName != "" {
+			release.DirectorComponentName = payload.DirectorComponentName
+		}
+
+		if payload.MatchFunctionComponentName != "" {
+			release.MatchFunctionComponentName = payload.MatchFunctionComponentName
+		}
+
+		release.UpdatedAt = now()
+		matchmaker.releases[release.Version] = release
+
+		writeJSON(w, http.StatusOK, release)
+	})
+
+	mux.HandleFunc("DELETE /v1/aom/matchmaker/{name}/release/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if matchmaker, release, ok := lookupRelease(w, r); ok {
+			delete(matchmaker.releases, release.Version)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	})
+
+	mux.HandleFunc("POST /v1/aom/matchmaker/{name}/release/managed", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerManagedReleaseCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		matchmaker, ok := s.lookupMatchmaker(w, r)
+		if !ok {
+			return
+		}
+
+		if _, ok := s.releaseConfigs[payload.ReleaseConfigName]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Release config %s not found", payload.ReleaseConfigName))
+			return
+		}
+
+		release := edgegap.MatchmakerManagedRelease{
+			Version:           payload.Version,
+			ReleaseConfigName: payload.ReleaseConfigName,
+			CreatedAt:         now(),
+			UpdatedAt:         now(),
+		}
+
+		matchmaker.managed[release.Version] = release
+
+		writeJSON(w, http.StatusOK, release)
+	})
+
+	lookupManaged := func(w http.ResponseWriter, r *http.Request) (*matchmakerState, edgegap.MatchmakerManagedRelease, bool) {
+		matchmaker, ok := s.lookupMatchmaker(w, r)
+		if !ok {
+			return nil, edgegap.MatchmakerManagedRelease{}, false
+		}
+
+		release, ok := matchmaker.managed[r.PathValue("version")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Managed release %s not found", r.PathValue("version")))
+			return nil, edgegap.MatchmakerManagedRelease{}, false
+		}
+
+		return matchmaker, release, true
+	}
+
+	mux.HandleFunc("GET /v1/aom/matchmaker/{name}/release/managed/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, release, ok := lookupManaged(w, r); ok {
+			writeJSON(w, http.StatusOK, release)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/aom/matchmaker/{name}/release/managed/{version}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerManagedReleaseCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		matchmaker, release, ok := lookupManaged(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.ReleaseConfigName != "" {
+			release.ReleaseConfigName = payload.ReleaseConfigName
+		}
+
+		release.UpdatedAt = now()
+		matchmaker.managed[release.Version] = release
+
+		writeJSON(w, http.StatusOK, release)
+	})
+
+	mux.HandleFunc("DELETE /v1/aom/matchmaker/{name}/release/managed/{version}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if matchmaker, release, ok := lookupManaged(w, r); ok {
+			delete(matchmaker.managed, release.Version)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	})
+
+	mux.HandleFunc("POST /v1/aom/release/config", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerReleaseConfig
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.releaseConfigs[payload.Name]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Release config %s already exists", payload.Name))
+			return
+		}
+
+		payload.CreatedAt = now()
+		payload.UpdatedAt = payload.CreatedAt
+		s.releaseConfigs[payload.Name] = payload
+
+		writeJSON(w, http.StatusOK, payload)
+	})
+
+	mux.HandleFunc("GET /v1/aom/release/config", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		list := edgegap.MatchmakerReleaseConfigListRes{Data: sortedValues(s.releaseConfigs)}
+		list.Count = len(list.Data)
+
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	lookupConfig := func(w http.ResponseWriter, r *http.Request) (edgegap.MatchmakerReleaseConfig, bool) {
+		config, ok := s.releaseConfigs[r.PathValue("name")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Release config %s not found", r.PathValue("name")))
+		}
+
+		return config, ok
+	}
+
+	mux.HandleFunc("GET /v1/aom/release/config/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if config, ok := lookupConfig(w, r); ok {
+			writeJSON(w, http.StatusOK, config)
+		}
+	})
+
+	mux.HandleFunc("PATCH /v1/aom/release/config/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.MatchmakerReleaseConfig
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		config, ok := lookupConfig(w, r)
+		if !ok {
+			return
+		}
+
+		if payload.Configuration != "" {
+			config.Configuration = payload.Configuration
+		}
+
+		config.UpdatedAt = now()
+		s.releaseConfigs[config.Name] = config
+
+		writeJSON(w, http.StatusOK, config)
+	})
+
+	mux.HandleFunc("DELETE /v1/aom/release/config/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if config, ok := lookupConfig(w, r); ok {
+			delete(s.releaseConfigs, config.Name)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package edgegaptest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// ipInformation returns the information of an IP address, located at the first location of the fake.
+func (s *Server) ipInformation(ip string) edgegap.IPInformation {
+	information := edgegap.IPInformation{IPAddress: ip, Type: "ipv4"}
+
+	if strings.Contains(ip, ":") {
+		information.Type = "ipv6"
+	}
+
+	if len(s.locations) > 0 {
+		location := s.locations[0]
+
+		information.Location = edgegap.IPAddressLookupLocation{
+			Continent: edgegap.IPAddressLookupLocationMeta{Name: location.Continent},
+			Country:   edgegap.IPAddressLookupLocationMeta{Name: location.Country},
+			Latitude:  int(location.Latitude),
+			Longitude: int(location.Longitude),
+		}
+	}
+
+	return information
+}
+
+func (s *Server) registerMisc(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/metrics/deployment/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.deployments[r.PathValue("id")]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", r.PathValue("id")))
+			return
+		}
+
+		sample := edgegap.MetricsModel{
+			Labels:     []string{"value"},
+			Datasets:   []string{"0"},
+			Timestamps: []string{now()},
+		}
+
+		writeJSON(w, http.StatusOK, edgegap.Metrics{
+			Total: edgegap.MetricsTotalModel{
+				RecieveTotal:   sample,
+				TransmitTotal:  sample,
+				DiskReadTotal:  sample,
+				DiskWriteTotal: sample,
+			},
+			CPU:     sample,
+			Memory:  sample,
+			Network: edgegap.MetricsNetworkModel{Recieve: sample, Transmit: sample},
+		})
+	})
+
+	mux.HandleFunc("POST /v1/telemetry/active-deployments", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.TelemetryCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		scores := []string{}
+
+		for _, id := range payload.Deployments {
+			if slices.Contains(s.deploymentIDs, id) {
+				scores = append(scores, id)
+			}
+		}
+
+		key := s.nextID()
+		s.telemetry[key] = edgegap.Telemetry{RetrievalKey: key, Scores: scores}
+
+		writeJSON(w, http.StatusOK, edgegap.TelemetryCreateRes{
+			RetrievalKey: key,
+			Expire:       time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		})
+	})
+
+	mux.HandleFunc("GET /v1/telemetry/active-deployments/{key}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		telemetry, ok := s.telemetry[r.PathValue("key")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Telemetry %s not found", r.PathValue("key")))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, telemetry)
+	})
+
+	mux.HandleFunc("GET /v1/ip", func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		writeJSON(w, http.StatusOK, edgegap.PublicIPResponse{IP: host})
+	})
+
+	mux.HandleFunc("GET /v1/ip/{ip}/lookup", func(w http.ResponseWriter, r *http.Request) {
+		if net.ParseIP(r.PathValue("ip")) == nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid IP address", r.PathValue("ip")))
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, s.ipInformation(r.PathValue("ip")))
+	})
+
+	mux.HandleFunc("POST /v1/ips/lookup", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.IPBulkInfoPayload
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		if len(payload.Addresses) > 20 {
+			writeError(w, http.StatusBadRequest, "A maximum of 20 addresses can be looked up at once")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		info := edgegap.IPBulkInfo{Addresses: []edgegap.IPInformation{}}
+
+		for _, ip := range payload.Addresses {
+			info.Addresses = append(info.Addresses, s.ipInformation(ip))
+		}
+
+		writeJSON(w, http.StatusOK, info)
+	})
+
+	listLocations := func(w http.ResponseWriter, r *http.Request) {
+		locationType := r.URL.Query().Get("type")
+		locations := []edgegap.LocationInfo{}
+
+		for _, location := range s.locations {
+			if locationType == "" || strings.EqualFold(location.Type, locationType) {
+				locations = append(locations, location)
+			}
+		}
+
+		writeJSON(w, http.StatusOK, edgegap.LocationListRes{List: locations, Message: []string{}})
+	}
+
+	mux.HandleFunc("GET /v1/locations", listLocations)
+	mux.HandleFunc("GET /v1/locations/{$}", listLocations)
+
+	mux.HandleFunc("GET /v1/locations/beacons", func(w http.ResponseWriter, r *http.Request) {
+		beacons := edgegap.LocationBeaconRes{List: s.locations}
+		beacons.Count = len(beacons.List)
+
+		writeJSON(w, http.StatusOK, beacons)
+	})
+}
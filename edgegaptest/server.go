@@ -0,0 +1,335 @@
+// Package edgegaptest provides an in-memory fake of the Edgegap API, backed by an httptest.Server,
+// to test code using an EdgegapClient without reaching the real API.
+//
+// The fake keeps its state in memory (applications, deployments, sessions, fleets, matchmakers...),
+// moves deployments through realistic statuses as they are polled and supports fault injection.
+//
+// A Cassette records the interactions with the real API to a file and replays them, so tests can run offline.
+package edgegaptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Token accepted by the fake when none is configured with WithToken.
+const DefaultToken = "token edgegaptest"
+
+// Statuses a deployment of the fake goes through.
+const (
+	StatusInitializing = edgegap.DeploymentStatusInitializing
+	StatusSeeking      = edgegap.DeploymentStatusSeeking
+	StatusDeploying    = edgegap.DeploymentStatusDeploying
+	StatusReady        = edgegap.DeploymentStatusReady
+	StatusError        = edgegap.DeploymentStatusError
+	StatusTerminated   = edgegap.DeploymentStatusTerminated
+)
+
+// Statuses a session of the fake goes through.
+const (
+	SessionStatusSeeking = edgegap.SessionStatusSeeking
+	SessionStatusLinked  = edgegap.SessionStatusLinked
+	SessionStatusReady   = edgegap.SessionStatusReady
+	SessionStatusError   = edgegap.SessionStatusError
+)
+
+// Page size used by the list endpoints when the request does not set one.
+const DefaultPageSize = 20
+
+// Fault makes the requests matching Method and Path fail with Status.
+type Fault struct {
+	Method  string        // HTTP method to match, empty matches every method
+	Path    string        // Path prefix to match, relative to the API version (i.e. /status/). Empty matches every path
+	Status  int           // Status code of the response
+	Message string        // Message of the error response
+	Header  http.Header   // Headers of the response, i.e. Retry-After
+	Delay   time.Duration // Time to wait before answering. With a zero Status, the request is only delayed
+	Times   int           // Number of requests failing before the fault is removed. 0 means forever
+
+	remaining int
+}
+
+// RecordedRequest is a request received by the fake.
+type RecordedRequest struct {
+	Method string
+	Path   string // Path, relative to the API version
+	Query  string
+	Body   []byte
+}
+
+// Server is an in-memory fake of the Edgegap API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	token      string
+	readyAfter int
+	locations  []edgegap.LocationInfo
+
+	mu       sync.Mutex
+	ids      int
+	faults   []*Fault
+	requests []RecordedRequest
+
+	apps           map[string]*appState
+	deployments    map[string]*deploymentState
+	deploymentIDs  []string
+	sessions       map[string]*sessionState
+	sessionIDs     []string
+	fleets         map[string]*fleetState
+	fleetNames     []string
+	telemetry      map[string]edgegap.Telemetry
+	matchmakers    map[string]*matchmakerState
+	components     map[string]*componentState
+	releaseConfigs map[string]edgegap.MatchmakerReleaseConfig
+}
+
+// Option configures a Server created with NewServer.
+type Option func(*Server)
+
+// Sets the Authorization header value the fake expects. An empty token disables the authentication.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// Sets the number of status polls a new deployment, or a session linked to it, needs before being ready.
+// Defaults to 3 : Seeking, Deploying then Ready.
+func WithReadyAfter(polls int) Option {
+	return func(s *Server) {
+		s.readyAfter = polls
+	}
+}
+
+// Sets the locations returned by the locations and beacons endpoints.
+func WithLocations(locations ...edgegap.LocationInfo) Option {
+	return func(s *Server) {
+		s.locations = locations
+	}
+}
+
+// Starts a new fake. It must be closed with Close once done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		token:      DefaultToken,
+		readyAfter: 3,
+		locations: []edgegap.LocationInfo{
+			{City: "Montreal", Country: "Canada", Continent: "North America", AdminiDivision: "Quebec", Timezone: "America/Toronto", Latitude: 45.5, Longitude: -73.56, Type: "Edge"},
+			{City: "Frankfurt", Country: "Germany", Continent: "Europe", AdminiDivision: "Hesse", Timezone: "Europe/Berlin", Latitude: 50.11, Longitude: 8.68, Type: "Edge"},
+		},
+		apps:           map[string]*appState{},
+		deployments:    map[string]*deploymentState{},
+		sessions:       map[string]*sessionState{},
+		fleets:         map[string]*fleetState{},
+		telemetry:      map[string]edgegap.Telemetry{},
+		matchmakers:    map[string]*matchmakerState{},
+		components:     map[string]*componentState{},
+		releaseConfigs: map[string]edgegap.MatchmakerReleaseConfig{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	s.registerApplications(mux)
+	s.registerDeployments(mux)
+	s.registerSessions(mux)
+	s.registerFleets(mux)
+	s.registerMatchmaker(mux)
+	s.registerMisc(mux)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+
+	return s
+}
+
+// Returns a client talking to the fake, authenticated with its token.
+func (s *Server) Client(opts ...edgegap.Option) *edgegap.EdgegapClient {
+	opts = append([]edgegap.Option{edgegap.WithBaseURL(s.URL)}, opts...)
+
+	return edgegap.NewEdgegapClient(s.token, opts...)
+}
+
+// Adds a fault to the fake. Faults are evaluated in the order they are added.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fault.remaining = fault.Times
+	s.faults = append(s.faults, &fault)
+}
+
+// Removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Returns the requests received so far, oldest first.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		path := strings.TrimPrefix(r.URL.Path, "/"+string(edgegap.VersionOne))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, RecordedRequest{
+			Method: r.Method,
+			Path:   path,
+			Query:  r.URL.RawQuery,
+			Body:   body,
+		})
+		fault := s.matchFault(r.Method, path)
+		s.mu.Unlock()
+
+		if fault != nil {
+			if fault.Delay > 0 {
+				select {
+				case <-time.After(fault.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			if fault.Status != 0 {
+				for key, values := range fault.Header {
+					w.Header()[key] = values
+				}
+
+				writeError(w, fault.Status, fault.Message)
+				return
+			}
+		}
+
+		// The self stop endpoint checks the token of the deployment itself.
+		selfStop := strings.HasPrefix(path, "/self/")
+
+		if s.token != "" && !selfStop && r.Header.Get("Authorization") != s.token {
+			writeError(w, http.StatusUnauthorized, "Invalid token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchFault returns a copy of the first fault matching the request, consuming one of its occurrences.
+// Must be called with mu held.
+func (s *Server) matchFault(method, path string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+
+		if !strings.HasPrefix(path, fault.Path) {
+			continue
+		}
+
+		matched := *fault
+
+		if fault.Times > 0 {
+			fault.remaining--
+
+			if fault.remaining <= 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return &matched
+	}
+
+	return nil
+}
+
+// nextID returns a new unique identifier, formatted like the Edgegap request IDs. Must be called with mu held.
+func (s *Server) nextID() string {
+	s.ids++
+
+	return fmt.Sprintf("%012x", 0x9f511e170000+s.ids)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	writeJSON(w, status, edgegap.ErrorResponse{Message: message})
+}
+
+// decode reads the JSON body of r into v, answering with a 400 on failure.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid body : %s", err))
+		return false
+	}
+
+	return true
+}
+
+// page returns the items of the requested page (page and limit query parameters) and the matching pagination.
+func page[T any](r *http.Request, items []T) ([]T, edgegap.Pagination) {
+	number, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if number < 1 {
+		number = 1
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+
+	pages := (len(items) + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+
+	start := min((number-1)*limit, len(items))
+	end := min(start+limit, len(items))
+
+	pagination := edgegap.Pagination{
+		Number:      number,
+		Paginator:   edgegap.Paginator{NumPages: pages},
+		HasNext:     number < pages,
+		HasPrevious: number > 1,
+	}
+
+	if pagination.HasNext {
+		pagination.NextPageNumber = number + 1
+	}
+
+	if pagination.HasPrevious {
+		pagination.PreviousPageNumber = number - 1
+	}
+
+	return items[start:end], pagination
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02 15:04:05.000000")
+}
@@ -0,0 +1,102 @@
+package edgegaptest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+func TestServerAuthentication(t *testing.T) {
+	server := edgegaptest.NewServer(edgegaptest.WithToken("token other"))
+	defer server.Close()
+
+	if _, err := edgegap.NewEdgegapClient("token wrong", edgegap.WithBaseURL(server.URL)).IPGet(context.Background()); !edgegap.IsUnauthorized(err) {
+		t.Errorf("IPGet() with a wrong token error = %v, want unauthorized", err)
+	}
+
+	if _, err := server.Client().IPGet(context.Background()); err != nil {
+		t.Errorf("IPGet() error = %v", err)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	server := edgegaptest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	server.InjectFault(edgegaptest.Fault{Method: http.MethodPost, Path: "/ip", Status: http.StatusInternalServerError})
+	server.InjectFault(edgegaptest.Fault{Path: "/ip", Status: http.StatusServiceUnavailable, Times: 2})
+
+	for _, want := range []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK} {
+		res, _ := client.IPGet(context.Background())
+
+		if got := res.Attempts[0].StatusCode; got != want {
+			t.Errorf("status = %d, want %d", got, want)
+		}
+	}
+
+	server.InjectFault(edgegaptest.Fault{Status: http.StatusTeapot})
+	server.ClearFaults()
+
+	if _, err := client.IPGet(context.Background()); err != nil {
+		t.Errorf("IPGet() after ClearFaults error = %v", err)
+	}
+}
+
+func TestServerRequests(t *testing.T) {
+	server := edgegaptest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	raw := "true"
+
+	client.MetricsByDeploymentID(context.Background(), "abc", edgegap.MetricsFilter{Raw: &raw})
+	client.IPGetInfoBulk(context.Background(), edgegap.IPBulkInfoPayload{Addresses: []string{"198.51.100.1"}})
+
+	requests := server.Requests()
+
+	if len(requests) != 2 {
+		t.Fatalf("%d requests recorded, want 2", len(requests))
+	}
+
+	if requests[0].Method != http.MethodGet || requests[0].Path != "/metrics/deployment/abc" || !strings.HasPrefix(requests[0].Query, "raw=true") {
+		t.Errorf("requests[0] = %+v, want GET /metrics/deployment/abc?raw=true", requests[0])
+	}
+
+	if requests[1].Path != "/ips/lookup" || len(requests[1].Body) == 0 {
+		t.Errorf("requests[1] = %+v, want POST /ips/lookup with a body", requests[1])
+	}
+}
+
+func TestServerDeploymentStatuses(t *testing.T) {
+	server := edgegaptest.NewServer(edgegaptest.WithReadyAfter(1))
+	defer server.Close()
+
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	res, err := server.Client().DeploymentGetStatus(context.Background(), id)
+	if err != nil {
+		t.Fatalf("DeploymentGetStatus() error = %v", err)
+	}
+
+	if res.Data.CurrentStatus != edgegaptest.StatusReady {
+		t.Errorf("CurrentStatus = %q, want %q", res.Data.CurrentStatus, edgegaptest.StatusReady)
+	}
+
+	server.SetDeploymentStatus(id, edgegaptest.StatusError)
+
+	if info, _ := server.Deployment(id); !info.Error {
+		t.Errorf("Deployment() = %+v, want a deployment in error", info)
+	}
+
+	server.SetDeploymentStatus(id, edgegaptest.StatusTerminated)
+
+	if _, err := server.Client().DeploymentContainerLogs(context.Background(), id); !edgegap.IsNotFound(err) {
+		t.Errorf("DeploymentContainerLogs() after stop error = %v, want not found", err)
+	}
+}
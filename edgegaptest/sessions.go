@@ -0,0 +1,364 @@
+package edgegaptest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+type sessionState struct {
+	session   edgegap.Session
+	requestID string // Request ID of the linked deployment
+	pinned    bool   // If the status was set by the test and must not follow the deployment
+}
+
+// Forces the status of a session, which then stops following its deployment.
+// SessionStatusError flags the session in error with message.
+func (s *Server) SetSessionStatus(id string, status string, message string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return false
+	}
+
+	session.pinned = true
+	session.session.Status = status
+	session.session.Error = message
+
+	return true
+}
+
+// sessionView returns the session as answered by the API, following the state of its deployment. Must be called with mu held.
+func (s *Server) sessionView(session *sessionState) edgegap.Session {
+	view := session.session
+	view.Users = append([]edgegap.SessionUser{}, session.session.Users...)
+	view.IPs = view.Users
+	view.UserCount = len(view.Users)
+
+	deployment, ok := s.deployments[session.requestID]
+
+	if ok {
+		view.Deployment = deployment.info
+		view.Linked = deployment.info.CurrentStatus != StatusTerminated
+		view.Ready = deployment.info.Running
+	}
+
+	if !session.pinned {
+		switch {
+		case ok && deployment.info.Error:
+			view.Status = SessionStatusError
+			view.Error = fmt.Sprintf("Deployment %s is in error", deployment.info.RequestID)
+		case view.Ready:
+			view.Status = SessionStatusReady
+		case view.Linked:
+			view.Status = SessionStatusLinked
+		default:
+			view.Status = SessionStatusSeeking
+		}
+	}
+
+	return view
+}
+
+// sessionFilterValues returns the values of a session for a filter field. Must be called with mu held.
+func (s *Server) sessionFilterValues(session *sessionState) func(field edgegap.EField) []string {
+	return func(field edgegap.EField) []string {
+		if field == edgegap.ESessionID {
+			return []string{session.session.ID}
+		}
+
+		if deployment, ok := s.deployments[session.requestID]; ok {
+			return deployment.filterValues(field)
+		}
+
+		return nil
+	}
+}
+
+// unlink removes a session from its deployment. Must be called with mu held.
+func (s *Server) unlink(session *sessionState) {
+	deployment, ok := s.deployments[session.requestID]
+	if !ok {
+		return
+	}
+
+	deployment.info.SocketsUsage -= len(session.session.Users)
+	deployment.info.Sessions = slices.DeleteFunc(deployment.info.Sessions, func(linked edgegap.DeploymentSession) bool {
+		return linked.SessionID == session.session.ID
+	})
+}
+
+// syncUsers reports the user count of a session to its deployment. Must be called with mu held.
+func (s *Server) syncUsers(session *sessionState, previous int) {
+	deployment, ok := s.deployments[session.requestID]
+	if !ok {
+		return
+	}
+
+	deployment.info.SocketsUsage += len(session.session.Users) - previous
+
+	for i, linked := range deployment.info.Sessions {
+		if linked.SessionID == session.session.ID {
+			deployment.info.Sessions[i].UserCount = len(session.session.Users)
+		}
+	}
+}
+
+func (s *Server) lookupSession(w http.ResponseWriter, r *http.Request) (*sessionState, bool) {
+	session, ok := s.sessions[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Session %s not found", r.PathValue("id")))
+		return nil, false
+	}
+
+	return session, true
+}
+
+func (s *Server) registerSessions(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/session", func(w http.ResponseWriter, r *http.Request) {
+		var payload edgegap.SessionCreate
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		app, ok := s.apps[payload.App]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("App %s not found", payload.App))
+			return
+		}
+
+		var users []edgegap.SessionUser
+
+		for _, ip := range payload.IPList {
+			users = append(users, edgegap.SessionUser{IP: ip})
+		}
+
+		for _, ip := range payload.GeoIPList {
+			users = append(users, edgegap.SessionUser{IP: ip.IP, Latitude: int(ip.Latitude), Longitude: int(ip.Longitude)})
+		}
+
+		var deployment *deploymentState
+
+		if payload.DeploymentRequestID != "" {
+			deployment, ok = s.deployments[payload.DeploymentRequestID]
+
+			if !ok || deployment.info.CurrentStatus == StatusTerminated {
+				writeError(w, http.StatusNotFound, fmt.Sprintf("Deployment %s not found", payload.DeploymentRequestID))
+				return
+			}
+
+			deployment.info.SocketsUsage += len(users)
+		} else {
+			var status int
+			var message string
+
+			deployment, status, message = s.createDeployment(payload.App, payload.Version, len(users), nil)
+			if deployment == nil {
+				writeError(w, status, message)
+				return
+			}
+		}
+
+		kind := string(edgegap.SessionDefault)
+		if version := app.versions[deployment.info.AppVersion]; version != nil && version.version.SessionConfig.Kind != "" {
+			kind = string(version.version.SessionConfig.Kind)
+		}
+
+		session := &sessionState{
+			session: edgegap.Session{
+				ID:         s.nextID() + "-S",
+				Kind:       kind,
+				CreateTime: now(),
+				Users:      users,
+				WebhookURL: payload.WebhookURL,
+			},
+			requestID: deployment.info.RequestID,
+		}
+
+		deployment.info.Sessions = append(deployment.info.Sessions, edgegap.DeploymentSession{
+			SessionID: session.session.ID,
+			Kind:      kind,
+			UserCount: len(users),
+		})
+
+		s.sessions[session.session.ID] = session
+		s.sessionIDs = append(s.sessionIDs, session.session.ID)
+
+		writeJSON(w, http.StatusOK, edgegap.SessionCreateRes{
+			SessionID:           session.session.ID,
+			App:                 payload.App,
+			Version:             deployment.info.AppVersion,
+			DeploymentRequestId: deployment.info.RequestID,
+			Selectors:           payload.Selectors,
+			WebhookURL:          payload.WebhookURL,
+		})
+	})
+
+	mux.HandleFunc("GET /v1/session", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		sessions := make([]edgegap.Session, 0, len(s.sessionIDs))
+
+		for _, id := range s.sessionIDs {
+			sessions = append(sessions, s.sessionView(s.sessions[id]))
+		}
+
+		data, pagination := page(r, sessions)
+
+		writeJSON(w, http.StatusOK, edgegap.ResponseBody[edgegap.Session]{
+			Count:      len(sessions),
+			Data:       data,
+			Success:    true,
+			Pagination: pagination,
+		})
+	})
+
+	mux.HandleFunc("GET /v1/session/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if session, ok := s.lookupSession(w, r); ok {
+			// The linked deployment moves forward as the session is polled, like when its status is.
+			if deployment, ok := s.deployments[session.requestID]; ok {
+				deployment.poll(s.readyAfter)
+			}
+
+			writeJSON(w, http.StatusOK, s.sessionView(session))
+		}
+	})
+
+	mux.HandleFunc("DELETE /v1/session/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		session, ok := s.lookupSession(w, r)
+		if !ok {
+			return
+		}
+
+		s.unlink(session)
+		delete(s.sessions, session.session.ID)
+		s.sessionIDs = remove(s.sessionIDs, session.session.ID)
+
+		writeJSON(w, http.StatusOK, edgegap.SessionDeleteRes{
+			Message:   fmt.Sprintf("Session %s deleted", session.session.ID),
+			SessionID: session.session.ID,
+			CustomID:  session.session.CustomID,
+		})
+	})
+
+	mux.HandleFunc("GET /v1/session/{id}/users", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if session, ok := s.lookupSession(w, r); ok {
+			writeJSON(w, http.StatusOK, edgegap.SessionUserRes{Users: append([]edgegap.SessionUser{}, session.session.Users...)})
+		}
+	})
+
+	mux.HandleFunc("PUT /v1/session/{id}/users", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			IPList []string `json:"ip_list"`
+		}
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		session, ok := s.lookupSession(w, r)
+		if !ok {
+			return
+		}
+
+		previous := len(session.session.Users)
+
+		for _, ip := range payload.IPList {
+			known := slices.ContainsFunc(session.session.Users, func(user edgegap.SessionUser) bool {
+				return user.IP == ip
+			})
+
+			if !known {
+				session.session.Users = append(session.session.Users, edgegap.SessionUser{IP: ip})
+			}
+		}
+
+		s.syncUsers(session, previous)
+
+		writeJSON(w, http.StatusOK, edgegap.SessionUserRes{Users: append([]edgegap.SessionUser{}, session.session.Users...)})
+	})
+
+	mux.HandleFunc("DELETE /v1/session/{id}/users", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			IPList []string `json:"ip_list"`
+		}
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		session, ok := s.lookupSession(w, r)
+		if !ok {
+			return
+		}
+
+		previous := len(session.session.Users)
+
+		session.session.Users = slices.DeleteFunc(session.session.Users, func(user edgegap.SessionUser) bool {
+			return slices.Contains(payload.IPList, user.IP)
+		})
+
+		s.syncUsers(session, previous)
+
+		writeJSON(w, http.StatusOK, edgegap.SessionUserRes{Users: append([]edgegap.SessionUser{}, session.session.Users...)})
+	})
+
+	mux.HandleFunc("POST /v1/sessions/bulk-stop", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Filters []edgegap.Filter `json:"filters"`
+		}
+
+		if !decode(w, r, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		processable := []string{}
+
+		for _, id := range s.sessionIDs {
+			ok, err := matchFilters(payload.Filters, s.sessionFilterValues(s.sessions[id]))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			if ok {
+				processable = append(processable, id)
+			}
+		}
+
+		for _, id := range processable {
+			s.unlink(s.sessions[id])
+			delete(s.sessions, id)
+			s.sessionIDs = remove(s.sessionIDs, id)
+		}
+
+		writeJSON(w, http.StatusOK, edgegap.SessionBulkDeleteRes{Processable: processable})
+	})
+}
@@ -0,0 +1,222 @@
+package edgegap
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrPortNotFound is returned when a deployment has no port with the requested name or internal port.
+var ErrPortNotFound = errors.New("edgegap: port not found")
+
+// ErrNoHost is returned when a deployment has neither a FQDN nor a public IP yet, i.e. it is not ready.
+var ErrNoHost = errors.New("edgegap: deployment has no host yet")
+
+// Endpoint is where players connect to a port of a deployment.
+type Endpoint struct {
+	Name     string   `json:"name"`            // Name of the port
+	Host     string   `json:"host"`            // FQDN of the deployment, or its public IP when it has none
+	Port     int      `json:"port"`            // External port players connect to
+	Internal int      `json:"internal_port"`   // Port the game server listens on inside the container
+	Protocol Protocol `json:"protocol"`        // Protocol of the port
+	TLS      bool     `json:"tls"`             // If the connection is encrypted, by the protocol or a TLS upgrade
+	Proxy    int      `json:"proxy,omitempty"` // Port of the TLS upgrade proxy in front of the game server, when there is one
+	URL      string   `json:"url"`             // URL to connect to, i.e. wss://host:port or udp://host:port
+}
+
+// Returns the host and port, as accepted by net.Dial.
+func (e Endpoint) Address() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+// Connection describes how to connect to a deployment. It is meant to be serialized to JSON and handed to game clients.
+type Connection struct {
+	RequestID string              `json:"request_id"` // Request ID of the deployment
+	Host      string              `json:"host"`       // FQDN of the deployment, or its public IP when it has none
+	PublicIP  string              `json:"public_ip"`  // Public IP of the deployment
+	Endpoints map[string]Endpoint `json:"endpoints"`  // Endpoints of the ports, by name
+}
+
+// Returns the endpoint of the port with the given name.
+func (d Deployment) Endpoint(name string) (Endpoint, error) {
+	return endpointByName(d.Ports, d.FQDN, d.PublicIP, name)
+}
+
+// Returns the endpoint of the port mapped to an internal port, the one the game server listens on.
+func (d Deployment) EndpointFor(internal int) (Endpoint, error) {
+	return endpointByInternal(d.Ports, d.FQDN, d.PublicIP, internal)
+}
+
+// Returns the endpoints of every port, sorted by name.
+func (d Deployment) Endpoints() ([]Endpoint, error) {
+	return endpoints(d.Ports, d.FQDN, d.PublicIP)
+}
+
+// Returns the connection descriptor of the deployment.
+func (d Deployment) Connection() (*Connection, error) {
+	return connection(d.RequestID, d.Ports, d.FQDN, d.PublicIP)
+}
+
+// Returns the endpoint of the port with the given name.
+func (d DeploymentInfo) Endpoint(name string) (Endpoint, error) {
+	return endpointByName(d.Ports, d.FDQN, d.PublicIP, name)
+}
+
+// Returns the endpoint of the port mapped to an internal port, the one the game server listens on.
+func (d DeploymentInfo) EndpointFor(internal int) (Endpoint, error) {
+	return endpointByInternal(d.Ports, d.FDQN, d.PublicIP, internal)
+}
+
+// Returns the endpoints of every port, sorted by name.
+func (d DeploymentInfo) Endpoints() ([]Endpoint, error) {
+	return endpoints(d.Ports, d.FDQN, d.PublicIP)
+}
+
+// Returns the connection descriptor of the deployment.
+func (d DeploymentInfo) Connection() (*Connection, error) {
+	return connection(d.RequestID, d.Ports, d.FDQN, d.PublicIP)
+}
+
+func endpointByName(ports map[string]PortDetails, fqdn, publicIP, name string) (Endpoint, error) {
+	if port, ok := ports[name]; ok {
+		return resolveEndpoint(name, port, fqdn, publicIP)
+	}
+
+	// The ports are keyed by name, some payloads only carry it in the details.
+	for _, key := range slices.Sorted(maps.Keys(ports)) {
+		if ports[key].Name == name {
+			return resolveEndpoint(name, ports[key], fqdn, publicIP)
+		}
+	}
+
+	return Endpoint{}, fmt.Errorf("%w : no port named %q", ErrPortNotFound, name)
+}
+
+func endpointByInternal(ports map[string]PortDetails, fqdn, publicIP string, internal int) (Endpoint, error) {
+	for _, name := range slices.Sorted(maps.Keys(ports)) {
+		if ports[name].Internal == internal {
+			return resolveEndpoint(name, ports[name], fqdn, publicIP)
+		}
+	}
+
+	return Endpoint{}, fmt.Errorf("%w : no port mapped to internal port %d", ErrPortNotFound, internal)
+}
+
+func endpoints(ports map[string]PortDetails, fqdn, publicIP string) ([]Endpoint, error) {
+	var resolved []Endpoint
+
+	for _, name := range slices.Sorted(maps.Keys(ports)) {
+		endpoint, err := resolveEndpoint(name, ports[name], fqdn, publicIP)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved = append(resolved, endpoint)
+	}
+
+	return resolved, nil
+}
+
+func connection(requestID string, ports map[string]PortDetails, fqdn, publicIP string) (*Connection, error) {
+	resolved, err := endpoints(ports, fqdn, publicIP)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &Connection{RequestID: requestID, Host: cmp.Or(fqdn, publicIP), PublicIP: publicIP, Endpoints: map[string]Endpoint{}}
+
+	for _, endpoint := range resolved {
+		conn.Endpoints[endpoint.Name] = endpoint
+	}
+
+	return conn, nil
+}
+
+// resolveEndpoint combines a port with the host of its deployment. The Link of the port, when set, is the address
+// given by the API and takes precedence.
+func resolveEndpoint(name string, port PortDetails, fqdn, publicIP string) (Endpoint, error) {
+	endpoint := Endpoint{
+		Name:     name,
+		Host:     cmp.Or(fqdn, publicIP),
+		Port:     port.External,
+		Internal: port.Internal,
+		Protocol: normalizeProtocol(port.Protocol),
+		Proxy:    port.Proxy,
+	}
+
+	if host, linkPort, ok := parseLink(port.Link); ok {
+		endpoint.Host = host
+
+		if linkPort != 0 {
+			endpoint.Port = linkPort
+		}
+	}
+
+	if endpoint.Host == "" {
+		return Endpoint{}, fmt.Errorf("%w : port %q can not be resolved", ErrNoHost, name)
+	}
+
+	var scheme string
+
+	switch endpoint.Protocol {
+	case ProtocolWS:
+		scheme, endpoint.TLS = "ws", port.TLSUpgrade
+		if port.TLSUpgrade {
+			scheme = "wss"
+		}
+	case ProtocolWSS:
+		scheme, endpoint.TLS = "wss", true
+	case ProtocolHTTP:
+		scheme, endpoint.TLS = "http", port.TLSUpgrade
+		if port.TLSUpgrade {
+			scheme = "https"
+		}
+	case ProtocolHTTPS:
+		scheme, endpoint.TLS = "https", true
+	case ProtocolUDP:
+		scheme = "udp"
+	default:
+		// TCP, and TCP/UDP ports which accept TCP connections.
+		scheme, endpoint.TLS = "tcp", port.TLSUpgrade
+	}
+
+	endpoint.URL = (&url.URL{Scheme: scheme, Host: endpoint.Address()}).String()
+
+	return endpoint, nil
+}
+
+// parseLink returns the host and port of the link of a port, which comes either as a URL or as host:port.
+func parseLink(link string) (string, int, bool) {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return "", 0, false
+	}
+
+	if u, err := url.Parse(link); err == nil && u.Host != "" {
+		port, _ := strconv.Atoi(u.Port())
+		return u.Hostname(), port, true
+	}
+
+	if host, port, err := net.SplitHostPort(link); err == nil {
+		external, _ := strconv.Atoi(port)
+		return host, external, true
+	}
+
+	return link, 0, true
+}
+
+// normalizeProtocol maps the protocol of a port to the Protocol constants, the misspelled TCP/UDP included.
+func normalizeProtocol(protocol string) Protocol {
+	switch p := Protocol(strings.ToUpper(strings.TrimSpace(protocol))); p {
+	case ProtocolTCPUDP, ProtocolTCPAndUDP:
+		return ProtocolTCPUDP
+	default:
+		return p
+	}
+}
@@ -0,0 +1,88 @@
+package edgegap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+var connectable = edgegap.DeploymentInfo{
+	RequestID: "9f511e17dfa4",
+	FDQN:      "9f511e17dfa4.pr.edgegap.net",
+	PublicIP:  "203.0.113.7",
+	Ports: map[string]edgegap.PortDetails{
+		"gameport": {External: 31504, Internal: 7777, Protocol: "UDP"},
+		"web":      {External: 31505, Internal: 8080, Protocol: "WS", TLSUpgrade: true, Proxy: 8081},
+		"query":    {External: 31506, Internal: 27015, Protocol: "TPC/UDP", Link: "203.0.113.7:31506"},
+	},
+}
+
+func TestDeploymentEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		protocol edgegap.Protocol
+		tls      bool
+	}{
+		{"gameport", "udp://9f511e17dfa4.pr.edgegap.net:31504", edgegap.ProtocolUDP, false},
+		{"web", "wss://9f511e17dfa4.pr.edgegap.net:31505", edgegap.ProtocolWS, true},
+		{"query", "tcp://203.0.113.7:31506", edgegap.ProtocolTCPUDP, false},
+	}
+
+	for _, test := range tests {
+		endpoint, err := connectable.Endpoint(test.name)
+		if err != nil {
+			t.Fatalf("Endpoint(%q) error = %v", test.name, err)
+		}
+
+		if endpoint.URL != test.url || endpoint.Protocol != test.protocol || endpoint.TLS != test.tls {
+			t.Errorf("Endpoint(%q) = %+v, want %s over %s, tls %v", test.name, endpoint, test.url, test.protocol, test.tls)
+		}
+	}
+
+	endpoint, err := connectable.EndpointFor(7777)
+	if err != nil || endpoint.Name != "gameport" || endpoint.Address() != "9f511e17dfa4.pr.edgegap.net:31504" {
+		t.Errorf("EndpointFor(7777) = %+v, %v, want gameport", endpoint, err)
+	}
+
+	if _, err := connectable.Endpoint("voice"); !errors.Is(err, edgegap.ErrPortNotFound) {
+		t.Errorf("Endpoint(\"voice\") error = %v, want ErrPortNotFound", err)
+	}
+
+	pending := edgegap.Deployment{Ports: map[string]edgegap.PortDetails{"gameport": {External: 31504, Internal: 7777, Protocol: "UDP"}}}
+
+	if _, err := pending.Endpoint("gameport"); !errors.Is(err, edgegap.ErrNoHost) {
+		t.Errorf("Endpoint() without a host error = %v, want ErrNoHost", err)
+	}
+}
+
+func TestDeploymentConnection(t *testing.T) {
+	conn, err := connectable.Connection()
+	if err != nil {
+		t.Fatalf("Connection() error = %v", err)
+	}
+
+	data, err := json.Marshal(conn)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded edgegap.Connection
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if !strings.Contains(string(data), `"protocol":"TCP/UDP"`) {
+		t.Errorf("Connection() JSON = %s, want the TCP/UDP protocol spelled right", data)
+	}
+
+	web := decoded.Endpoints["web"]
+
+	if decoded.Host != "9f511e17dfa4.pr.edgegap.net" || len(decoded.Endpoints) != 3 || web.URL != "wss://9f511e17dfa4.pr.edgegap.net:31505" || web.Proxy != 8081 {
+		t.Errorf("Connection() round trip = %+v", decoded)
+	}
+}
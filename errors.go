@@ -0,0 +1,141 @@
+package edgegap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the API answers with an unsuccessful status code. Use errors.As to retrieve it.
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Message    string // Message returned by the API, or the status text when none was given
+	Method     string // HTTP method of the request
+	Endpoint   string // Endpoint of the request, relative to the API version (i.e. /status/{request_id})
+	Body       []byte // Raw body of the response
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API Error : %s %s returned %d : %s", e.Method, e.Endpoint, e.StatusCode, e.Message)
+}
+
+// ValidationError is returned when a request is rejected before being sent, listing every problem found in it.
+type ValidationError struct {
+	Problems []FieldProblem // Problems, in the order they were found
+}
+
+// FieldProblem is a problem of a single field of a request.
+type FieldProblem struct {
+	Field   string // JSON name of the field, with the index of the item for lists (i.e. ip_list[2])
+	Message string // What is wrong with the field
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+
+	for i, problem := range e.Problems {
+		problems[i] = problem.Field + " " + problem.Message
+	}
+
+	return "Validation Error : " + strings.Join(problems, ", ")
+}
+
+// add records a problem of field.
+func (e *ValidationError) add(field string, format string, args ...interface{}) {
+	e.Problems = append(e.Problems, FieldProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// orNil returns e when it holds problems, nil otherwise.
+func (e *ValidationError) orNil() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Messages sent by the API when no location can host a deployment.
+var noCapacityMessages = []string{
+	"capacity",
+	"no available",
+	"not enough",
+}
+
+func newAPIError(call *Call, statusCode int, message string, body []byte) *APIError {
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+
+	return &APIError{
+		StatusCode: statusCode,
+		Message:    message,
+		Method:     call.Method,
+		Endpoint:   call.Path,
+		Body:       body,
+	}
+}
+
+func hasStatus(err error, statusCodes ...int) bool {
+	var apiErr *APIError
+
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, code := range statusCodes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports if err is an APIError caused by a missing resource (404).
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// Reports if err is an APIError caused by a missing or invalid token (401).
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// Reports if err is an APIError caused by a token lacking the permission for the request (403).
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// Reports if err is an APIError caused by too many requests (429).
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// Reports if err is an APIError caused by a conflict with an existing resource (409).
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// Reports if err is an APIError caused by a lack of capacity to serve the request, i.e. no location able to host a deployment.
+func IsNoCapacity(err error) bool {
+	var apiErr *APIError
+
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.StatusCode == http.StatusInsufficientStorage {
+		return true
+	}
+
+	message := strings.ToLower(apiErr.Message)
+
+	for _, candidate := range noCapacityMessages {
+		if strings.Contains(message, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestAPIError(t *testing.T) {
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Deployment missing not found"}`)
+	})
+
+	_, err := client.DeploymentGetStatus(context.Background(), "missing")
+
+	var apiErr *edgegap.APIError
+
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("DeploymentGetStatus() error = %v, want an APIError", err)
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Deployment missing not found" {
+		t.Errorf("APIError = %+v, want the status and message of the response", apiErr)
+	}
+
+	if apiErr.Method != http.MethodGet || apiErr.Endpoint != "/status/missing" || len(apiErr.Body) == 0 {
+		t.Errorf("APIError = %+v, want the method, endpoint and body of the request", apiErr)
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		is      func(error) bool
+	}{
+		{http.StatusNotFound, "", edgegap.IsNotFound},
+		{http.StatusUnauthorized, "", edgegap.IsUnauthorized},
+		{http.StatusForbidden, "", edgegap.IsForbidden},
+		{http.StatusTooManyRequests, "", edgegap.IsRateLimited},
+		{http.StatusConflict, "", edgegap.IsConflict},
+		{http.StatusInsufficientStorage, "", edgegap.IsNoCapacity},
+		{http.StatusBadRequest, "No available location with enough capacity", edgegap.IsNoCapacity},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				fmt.Fprintf(w, `{"message":%q}`, tt.message)
+			})
+
+			_, err := client.IPGet(context.Background())
+
+			if !tt.is(err) {
+				t.Errorf("error = %v, not classified as expected", err)
+			}
+
+			if edgegap.IsNotFound(fmt.Errorf("wrapped : %w", err)) != (tt.status == http.StatusNotFound) {
+				t.Errorf("IsNotFound() does not unwrap %v", err)
+			}
+		})
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token right" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Invalid token"}`)
+		}
+	})
+
+	_, err := client.ApplicationGetList(context.Background())
+
+	if !edgegap.IsUnauthorized(err) {
+		t.Fatalf("ApplicationGetList() error = %v, want an unauthorized error", err)
+	}
+}
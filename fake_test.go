@@ -0,0 +1,35 @@
+package edgegap_test
+
+import (
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+// newTestClient starts a fake API and returns it with a client talking to it.
+func newTestClient(t *testing.T, opts ...edgegap.Option) (*edgegaptest.Server, *edgegap.EdgegapClient) {
+	t.Helper()
+
+	server := edgegaptest.NewServer()
+	t.Cleanup(server.Close)
+
+	return server, server.Client(opts...)
+}
+
+// seedApp adds an application "game" with a version "v1" exposing a UDP game port.
+func seedApp(server *edgegaptest.Server) {
+	server.AddApplication(
+		edgegap.Application{Name: "game", IsActive: true},
+		edgegap.ApplicationVersion{
+			Name:          "v1",
+			DockerRepo:    "registry.edgegap.com",
+			DockerImage:   "studio/game",
+			DockerTag:     "1.0.0",
+			SessionConfig: edgegap.ApplicationVersionSession{Kind: edgegap.SessionMatch, Sockets: 10},
+			Ports: []edgegap.ApplicationPort{
+				{Port: 7777, Protocol: edgegap.ProtocolUDP, Name: "gameport"},
+			},
+		},
+	)
+}
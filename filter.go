@@ -0,0 +1,185 @@
+package edgegap
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FilterSubject is a value a Filter can be evaluated on locally, see MatchFilters.
+// Deployment, DeploymentInfo and Session are filter subjects.
+type FilterSubject interface {
+	// Returns the values of the subject for a filter field, and false when the subject does not know them.
+	FilterValues(field EField) ([]string, bool)
+}
+
+// FilterFunc adapts a function to a FilterSubject.
+type FilterFunc func(field EField) ([]string, bool)
+
+// Returns f(field).
+func (f FilterFunc) FilterValues(field EField) ([]string, bool) {
+	return f(field)
+}
+
+// FilterError is returned when a filter can not be evaluated locally, because it is malformed or the subject does
+// not know the values of its field (i.e. the city of a Deployment, see WithLocation).
+type FilterError struct {
+	Filter  Filter // The filter
+	Message string // Why it can not be evaluated
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("Filter Error : %s %s : %s", e.Filter.FilterType, e.Filter.Field, e.Message)
+}
+
+// Reports if subject matches every filter, with the semantics of the API :
+//   - EAny matches when the subject has at least one of the values. A filter without a type is an EAny filter.
+//   - EAll matches when the subject has every value.
+//   - ENot matches when the subject has none of the values.
+//
+// An empty filter list matches everything, as it does for the bulk deletes.
+func MatchFilters(filters []Filter, subject FilterSubject) (bool, error) {
+	matched := true
+
+	// Every filter is checked, so a malformed filter is reported even after a mismatch.
+	for _, filter := range filters {
+		if !isFilterField(filter.Field) {
+			return false, &FilterError{Filter: filter, Message: "unknown field"}
+		}
+
+		values, known := subject.FilterValues(filter.Field)
+		if !known {
+			return false, &FilterError{Filter: filter, Message: fmt.Sprintf("the values of the field are not known for %T", subject)}
+		}
+
+		ok, err := MatchFilter(filter, values)
+		if err != nil {
+			return false, err
+		}
+
+		matched = matched && ok
+	}
+
+	return matched, nil
+}
+
+// Reports if values, the values of a subject for the field of filter, match filter. See MatchFilters.
+func MatchFilter(filter Filter, values []string) (bool, error) {
+	if !isFilterField(filter.Field) {
+		return false, &FilterError{Filter: filter, Message: "unknown field"}
+	}
+
+	matches := 0
+
+	for _, value := range filter.Values {
+		if slices.Contains(values, value) {
+			matches++
+		}
+	}
+
+	switch filter.FilterType {
+	case EAny, "":
+		return matches > 0, nil
+	case EAll:
+		return matches == len(filter.Values), nil
+	case ENot:
+		return matches == 0, nil
+	}
+
+	return false, &FilterError{Filter: filter, Message: "unknown filter type"}
+}
+
+// Returns the items matching every filter, in their order. See MatchFilters.
+func Matching[T FilterSubject](items []T, filters []Filter) ([]T, error) {
+	var matching []T
+
+	for _, item := range items {
+		ok, err := MatchFilters(filters, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matching = append(matching, item)
+		}
+	}
+
+	return matching, nil
+}
+
+// Returns subject with the values of the location fields taken from location, i.e. the LocationInfo of the city it
+// runs in, as listed by Locations.List. The other fields are still evaluated on subject.
+func WithLocation(subject FilterSubject, location LocationInfo) FilterSubject {
+	return FilterFunc(func(field EField) ([]string, bool) {
+		switch field {
+		case ECity:
+			return []string{location.City}, true
+		case ECountry:
+			return []string{location.Country}, true
+		case EContinent:
+			return []string{location.Continent}, true
+		case EAdminDivision:
+			return []string{location.AdminiDivision}, true
+		case ELocationTags:
+			return location.Tags, true
+		}
+
+		return subject.FilterValues(field)
+	})
+}
+
+// Returns the request ID and tags of the deployment. Its location and sessions are not known.
+func (d Deployment) FilterValues(field EField) ([]string, bool) {
+	switch field {
+	case ERequestID:
+		return []string{d.RequestID}, true
+	case EDeploymentTag:
+		return d.Tags, true
+	}
+
+	return nil, false
+}
+
+// Returns the request ID, tags and session IDs of the deployment. Its location is only known by coordinates,
+// see WithLocation.
+func (d DeploymentInfo) FilterValues(field EField) ([]string, bool) {
+	switch field {
+	case ERequestID:
+		return []string{d.RequestID}, true
+	case EDeploymentTag:
+		return d.Tags, true
+	case ESessionID:
+		ids := make([]string, len(d.Sessions))
+
+		for i, session := range d.Sessions {
+			ids[i] = session.SessionID
+		}
+
+		return ids, true
+	}
+
+	return nil, false
+}
+
+// Returns the session ID, and the values of the deployment it is linked to for the other fields.
+// A session not linked to a deployment has no values for them.
+func (s Session) FilterValues(field EField) ([]string, bool) {
+	switch {
+	case field == ESessionID:
+		return []string{s.ID}, true
+	case s.Deployment.RequestID != "":
+		return s.Deployment.FilterValues(field)
+	case isFilterField(field):
+		return nil, true
+	}
+
+	return nil, false
+}
+
+func isFilterField(field EField) bool {
+	switch field {
+	case ECity, ECountry, EContinent, ERegion, EAdminDivision, ELocationTags, ESessionID, EDeploymentTag, ERequestID:
+		return true
+	}
+
+	return false
+}
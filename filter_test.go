@@ -0,0 +1,102 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestMatchFilters(t *testing.T) {
+	deployment := edgegap.DeploymentInfo{
+		RequestID: "9f511e17dfa4",
+		Tags:      []string{"ranked", "eu"},
+		Sessions:  []edgegap.DeploymentSession{{SessionID: "a1b2c3"}},
+	}
+
+	montreal := edgegap.WithLocation(deployment, edgegap.LocationInfo{City: "Montreal", Country: "Canada", Tags: []string{"premium"}})
+
+	tests := []struct {
+		filter edgegap.Filter
+		want   bool
+	}{
+		{edgegap.Filter{Field: edgegap.EDeploymentTag, FilterType: edgegap.EAny, Values: []string{"casual", "ranked"}}, true},
+		{edgegap.Filter{Field: edgegap.EDeploymentTag, FilterType: edgegap.EAll, Values: []string{"eu", "ranked"}}, true},
+		{edgegap.Filter{Field: edgegap.EDeploymentTag, FilterType: edgegap.EAll, Values: []string{"eu", "casual"}}, false},
+		{edgegap.Filter{Field: edgegap.EDeploymentTag, FilterType: edgegap.ENot, Values: []string{"casual"}}, true},
+		{edgegap.Filter{Field: edgegap.EDeploymentTag, Values: []string{"ranked"}}, true},
+		{edgegap.Filter{Field: edgegap.ESessionID, FilterType: edgegap.EAny, Values: []string{"a1b2c3"}}, true},
+		{edgegap.Filter{Field: edgegap.ECity, FilterType: edgegap.EAny, Values: []string{"Paris"}}, false},
+		{edgegap.Filter{Field: edgegap.ELocationTags, FilterType: edgegap.EAll, Values: []string{"premium"}}, true},
+	}
+
+	for _, test := range tests {
+		got, err := edgegap.MatchFilters([]edgegap.Filter{test.filter}, montreal)
+		if err != nil || got != test.want {
+			t.Errorf("MatchFilters(%+v) = %v, %v, want %v", test.filter, got, err, test.want)
+		}
+	}
+
+	var filterErr *edgegap.FilterError
+
+	city := []edgegap.Filter{{Field: edgegap.ECity, FilterType: edgegap.EAny, Values: []string{"Montreal"}}}
+
+	if _, err := edgegap.MatchFilters(city, deployment); !errors.As(err, &filterErr) {
+		t.Errorf("MatchFilters() on the city of a DeploymentInfo error = %v, want a FilterError", err)
+	}
+
+	unknownType := []edgegap.Filter{{Field: edgegap.ERequestID, FilterType: "some", Values: []string{"9f511e17dfa4"}}}
+
+	if _, err := edgegap.MatchFilters(unknownType, deployment); !errors.As(err, &filterErr) {
+		t.Errorf("MatchFilters() with an unknown filter type error = %v, want a FilterError", err)
+	}
+
+	unlinked := edgegap.Session{ID: "d4e5f6"}
+	notRanked := []edgegap.Filter{{Field: edgegap.EDeploymentTag, FilterType: edgegap.ENot, Values: []string{"ranked"}}}
+
+	if ok, err := edgegap.MatchFilters(notRanked, unlinked); err != nil || !ok {
+		t.Errorf("MatchFilters() on an unlinked session = %v, %v, want a match", ok, err)
+	}
+}
+
+func TestMatchingAgreesWithBulkDelete(t *testing.T) {
+	server, client := newTestClient(t)
+
+	for _, tags := range [][]string{{"ranked"}, {"ranked", "eu"}, {"casual"}, nil} {
+		server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", Tags: tags})
+	}
+
+	ctx := context.Background()
+
+	filters := []edgegap.Filter{
+		{Field: edgegap.EDeploymentTag, FilterType: edgegap.EAny, Values: []string{"ranked", "casual"}},
+		{Field: edgegap.EDeploymentTag, FilterType: edgegap.ENot, Values: []string{"eu"}},
+	}
+
+	list, err := client.Deployments.List(ctx)
+	if err != nil {
+		t.Fatalf("Deployments.List() error = %v", err)
+	}
+
+	matching, err := edgegap.Matching(list.Data.Data, filters)
+	if err != nil {
+		t.Fatalf("Matching() error = %v", err)
+	}
+
+	var preview []string
+
+	for _, deployment := range matching {
+		preview = append(preview, deployment.RequestID)
+	}
+
+	res, err := client.Deployments.BulkDelete(ctx, filters)
+	if err != nil {
+		t.Fatalf("Deployments.BulkDelete() error = %v", err)
+	}
+
+	if len(preview) != 2 || !slices.Equal(preview, res.Data.RequestIDs) {
+		t.Errorf("Matching() = %v, Deployments.BulkDelete() stopped %v, want the same 2 deployments", preview, res.Data.RequestIDs)
+	}
+}
@@ -5,9 +5,10 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"iter"
+	"net/http"
 )
 
 type FleetCreatePayload struct {
@@ -36,65 +37,111 @@ type FleetList struct {
 	Pagination Pagination `json:"pagination,omitempty"`
 }
 
+// FleetsService manages the fleets, see EdgegapClient.Fleets.
+type FleetsService struct {
+	client *EdgegapClient
+}
+
 // Create a fleet. A fleet is a top-level object; you must create child resources to work properly.
-func (e *EdgegapClient) FleetCreate(payload FleetCreatePayload) (*Response[Fleet], error) {
+func (s *FleetsService) Create(ctx context.Context, payload FleetCreatePayload) (*Response[Fleet], error) {
 	var response Fleet
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Post("/feet")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetCreate",
+		method:    http.MethodPost,
+		path:      "/fleet",
+		body:      payload,
 	}, &response)
 }
 
 // Retrieve a fleet with its details.
-func (e *EdgegapClient) FleetGet(name string) (*Response[Fleet], error) {
+func (s *FleetsService) Get(ctx context.Context, name string) (*Response[Fleet], error) {
 	var response Fleet
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/fleet/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetGet",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/fleet/%s", name),
 	}, &response)
 }
 
 // Update a fleet with new specifications
-func (e *EdgegapClient) FleetUpdate(name string, payload FleetCreatePayload) (*Response[Fleet], error) {
+func (s *FleetsService) Update(ctx context.Context, name string, payload FleetCreatePayload) (*Response[Fleet], error) {
 	var response Fleet
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Patch(fmt.Sprintf("/fleet/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetUpdate",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/fleet/%s", name),
+		body:      payload,
 	}, &response)
 }
 
 // Delete a fleet, its policies and links between the application versions.
-func (e *EdgegapClient) FleetDelete(name string) (*Response[interface{}], error) {
+func (s *FleetsService) Delete(ctx context.Context, name string) (*Response[interface{}], error) {
 	var response interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/fleet/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetDelete",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/fleet/%s", name),
 	}, &response)
 }
 
-// List all the fleets you own.
-func (e *EdgegapClient) FleetList() (*Response[FleetList], error) {
+// List all the fleets you own. Only the first page is returned, use ListPage or Iterate to get the others.
+func (s *FleetsService) List(ctx context.Context) (*Response[FleetList], error) {
 	var response FleetList
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/fleets")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetList",
+		method:    http.MethodGet,
+		path:      "/fleets",
 	}, &response)
 }
 
+// List a single page of the fleets you own.
+func (s *FleetsService) ListPage(ctx context.Context, params PaginationParams) (*Response[FleetList], error) {
+	var response FleetList
+
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetListPage",
+		method:    http.MethodGet,
+		path:      "/fleets" + params.GetParams(),
+	}, &response)
+}
+
+// Iterate over every fleet you own, fetching the pages as they are needed.
+func (s *FleetsService) Iterate(ctx context.Context) iter.Seq2[Fleet, error] {
+	return paginate(ctx, func(ctx context.Context, params PaginationParams) ([]Fleet, Pagination, error) {
+		res, err := s.ListPage(ctx, params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		return res.Data.Fleets, res.Data.Pagination, nil
+	})
+}
+
 // Link an application version to a fleet. By linking this version, the fleet will automatically create deployments of this version according to the fleet policies.
-func (e *EdgegapClient) FleetLinkApplication(fleet, app, version string) (*Response[FleetApplication], error) {
+func (s *FleetsService) Link(ctx context.Context, fleet, app, version string) (*Response[FleetApplication], error) {
 	var response FleetApplication
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Put(fmt.Sprintf("/fleet/%s/app/%s/version/%s", fleet, app, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetLinkApplication",
+		method:    http.MethodPut,
+		path:      fmt.Sprintf("/fleet/%s/app/%s/version/%s", fleet, app, version),
+		params:    map[string]string{paramAppName: app, paramAppVersion: version},
 	}, &response)
 }
 
 // Unlink an application version from a fleet. It will not delete the application version or the fleet
-func (e *EdgegapClient) FleetUnlinkApplication(fleet, app, version string) (*Response[interface{}], error) {
+func (s *FleetsService) Unlink(ctx context.Context, fleet, app, version string) (*Response[interface{}], error) {
 	var response interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/fleet/%s/app/%s/version/%s", fleet, app, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "FleetUnlinkApplication",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/fleet/%s/app/%s/version/%s", fleet, app, version),
+		params:    map[string]string{paramAppName: app, paramAppVersion: version},
 	}, &response)
 }
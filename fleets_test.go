@@ -0,0 +1,52 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestFleetLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	if _, err := client.FleetCreate(ctx, edgegap.FleetCreatePayload{Name: "eu"}); err != nil {
+		t.Fatalf("FleetCreate() error = %v", err)
+	}
+
+	if _, err := client.FleetCreate(ctx, edgegap.FleetCreatePayload{Name: "eu"}); !edgegap.IsConflict(err) {
+		t.Errorf("FleetCreate() duplicate error = %v, want conflict", err)
+	}
+
+	updated, err := client.FleetUpdate(ctx, "eu", edgegap.FleetCreatePayload{Name: "eu", Enabled: true})
+	if err != nil {
+		t.Fatalf("FleetUpdate() error = %v", err)
+	}
+
+	if !updated.Data.Enabled {
+		t.Errorf("FleetUpdate() Enabled = false, want true")
+	}
+
+	link, err := client.FleetLinkApplication(ctx, "eu", "game", "v1")
+	if err != nil {
+		t.Fatalf("FleetLinkApplication() error = %v", err)
+	}
+
+	if link.Data.AppName != "game" || link.Data.AppVersion != "v1" {
+		t.Errorf("FleetLinkApplication() = %+v, want game v1", link.Data)
+	}
+
+	if _, err := client.FleetUnlinkApplication(ctx, "eu", "game", "v1"); err != nil {
+		t.Fatalf("FleetUnlinkApplication() error = %v", err)
+	}
+
+	if _, err := client.FleetDelete(ctx, "eu"); err != nil {
+		t.Fatalf("FleetDelete() error = %v", err)
+	}
+
+	if _, err := client.FleetGet(ctx, "eu"); !edgegap.IsNotFound(err) {
+		t.Errorf("FleetGet() after delete error = %v, want not found", err)
+	}
+}
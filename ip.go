@@ -5,9 +5,9 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"net/http"
 )
 
 type PublicIPResponse struct {
@@ -40,29 +40,41 @@ type IPInformation struct {
 	Location  IPAddressLookupLocation `json:"location,omitempty"`
 }
 
+// IPService looks up IP addresses, see EdgegapClient.IP.
+type IPService struct {
+	client *EdgegapClient
+}
+
 // Retrieve your public IP address.
-func (e *EdgegapClient) IPGet() (*Response[PublicIPResponse], error) {
+func (s *IPService) Get(ctx context.Context) (*Response[PublicIPResponse], error) {
 	var response PublicIPResponse
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/ip")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "IPGet",
+		method:    http.MethodGet,
+		path:      "/ip",
 	}, &response)
 }
 
 // Lookup an IP address and return the associated information.
-func (e *EdgegapClient) IPGetInfo(ip string) (*Response[IPInformation], error) {
+func (s *IPService) Lookup(ctx context.Context, ip string) (*Response[IPInformation], error) {
 	var response IPInformation
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/ip/%s/lookup", ip))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "IPGetInfo",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/ip/%s/lookup", ip),
 	}, &response)
 }
 
 // Lookup IP addresses and return the associated information. Maximum of 20 IPs.
-func (e *EdgegapClient) IPGetInfoBulk(payload IPBulkInfoPayload) (*Response[IPBulkInfo], error) {
+func (s *IPService) LookupBulk(ctx context.Context, payload IPBulkInfoPayload) (*Response[IPBulkInfo], error) {
 	var response IPBulkInfo
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Post(fmt.Sprintf("/"))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "IPGetInfoBulk",
+		method:    http.MethodPost,
+		path:      "/ips/lookup",
+		body:      payload,
 	}, &response)
 }
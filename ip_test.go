@@ -0,0 +1,35 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestIPGetInfo(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	info, err := client.IPGetInfo(ctx, "2001:db8::1")
+	if err != nil {
+		t.Fatalf("IPGetInfo() error = %v", err)
+	}
+
+	if info.Data.Type != "ipv6" {
+		t.Errorf("Type = %q, want ipv6", info.Data.Type)
+	}
+
+	bulk, err := client.IPGetInfoBulk(ctx, edgegap.IPBulkInfoPayload{Addresses: []string{"198.51.100.1", "198.51.100.2"}})
+	if err != nil {
+		t.Fatalf("IPGetInfoBulk() error = %v", err)
+	}
+
+	if len(bulk.Data.Addresses) != 2 {
+		t.Errorf("IPGetInfoBulk() = %d addresses, want 2", len(bulk.Data.Addresses))
+	}
+
+	if _, err := client.IPGetInfo(ctx, "not-an-ip"); err == nil {
+		t.Error("IPGetInfo() error = nil, want a bad request")
+	}
+}
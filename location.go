@@ -5,9 +5,9 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"net/http"
 )
 
 type LocationInfo struct {
@@ -39,8 +39,13 @@ type LocationFilters struct {
 	Tags    string // Gets locations with tags. Set to: "true" to have the tags
 }
 
+// LocationsService lists the locations, see EdgegapClient.Locations.
+type LocationsService struct {
+	client *EdgegapClient
+}
+
 // List all the locations available to deploy on. You can specify an application and a version to filter out the locations that don’t have enough resources to deploy this application version.
-func (e *EdgegapClient) LocationListAll(filters LocationFilters) (*Response[LocationListRes], error) {
+func (s *LocationsService) List(ctx context.Context, filters LocationFilters) (*Response[LocationListRes], error) {
 	query := "?"
 
 	if filters.App != "" {
@@ -58,16 +63,20 @@ func (e *EdgegapClient) LocationListAll(filters LocationFilters) (*Response[Loca
 
 	var response LocationListRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/locations/%s", query))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "LocationListAll",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/locations/%s", query),
 	}, &response)
 }
 
 // List all the active location beacons. They can be used to ping them for your matchmaking system. You cannot deploy on beacons.
-func (e *EdgegapClient) LocationListAllBeacons() (*Response[LocationBeaconRes], error) {
+func (s *LocationsService) Beacons(ctx context.Context) (*Response[LocationBeaconRes], error) {
 	var response LocationBeaconRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/locations/beacons")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "LocationListAllBeacons",
+		method:    http.MethodGet,
+		path:      "/locations/beacons",
 	}, &response)
 }
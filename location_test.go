@@ -0,0 +1,30 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestLocationListAll(t *testing.T) {
+	_, client := newTestClient(t)
+
+	res, err := client.LocationListAll(context.Background(), edgegap.LocationFilters{Type: "Edge"})
+	if err != nil {
+		t.Fatalf("LocationListAll() error = %v", err)
+	}
+
+	if len(res.Data.List) != 2 {
+		t.Errorf("LocationListAll() = %d locations, want 2", len(res.Data.List))
+	}
+
+	beacons, err := client.LocationListAllBeacons(context.Background())
+	if err != nil {
+		t.Fatalf("LocationListAllBeacons() error = %v", err)
+	}
+
+	if beacons.Data.Count != len(beacons.Data.List) {
+		t.Errorf("Count = %d, want %d", beacons.Data.Count, len(beacons.Data.List))
+	}
+}
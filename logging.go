@@ -0,0 +1,222 @@
+package edgegap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LogOptions controls what LoggingMiddleware logs.
+type LogOptions struct {
+	Level      slog.Level // Level of the successful calls
+	ErrorLevel slog.Level // Level of the failed calls
+	LogBodies  bool       // If the request and response bodies are logged, with their secrets redacted
+	HashIPs    bool       // If player IPs are replaced by a hash in the logged paths and bodies
+	HashSalt   string     // Salt mixed to the IPs before hashing them
+}
+
+const redacted = "[REDACTED]"
+
+// Body fields whose value is never logged.
+var secretFields = map[string]bool{
+	"authorization":    true,
+	"password":         true,
+	"private_password": true,
+	"private_token":    true,
+	"token":            true,
+	"delete_token":     true,
+	"context_token":    true,
+}
+
+// Headers whose value is never logged.
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+}
+
+// Body fields holding player IPs.
+var ipFields = map[string]bool{
+	"ip":         true,
+	"ip_list":    true,
+	"ips":        true,
+	"ip_address": true,
+	"addresses":  true,
+}
+
+// Successful calls are logged at info level and failed ones at error level, without their bodies.
+func DefaultLogOptions() LogOptions {
+	return LogOptions{
+		Level:      slog.LevelInfo,
+		ErrorLevel: slog.LevelError,
+	}
+}
+
+// Logs every call of the client to logger using DefaultLogOptions.
+func WithLogger(logger *slog.Logger) Option {
+	return WithLogging(logger, DefaultLogOptions())
+}
+
+// Logs every call of the client to logger, see LoggingMiddleware.
+// The logging middleware is added after the middlewares registered by the previous options.
+func WithLogging(logger *slog.Logger, options LogOptions) Option {
+	return WithMiddleware(LoggingMiddleware(logger, options))
+}
+
+// LoggingMiddleware logs one record per call with its operation, status, attempts and latency.
+// Known secrets (tokens, passwords, hidden environment variables) are always redacted from the logged bodies and headers.
+func LoggingMiddleware(logger *slog.Logger, options LogOptions) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*Result, error) {
+			start := time.Now()
+			result, err := next(ctx, call)
+
+			level := options.Level
+			if err != nil {
+				level = options.ErrorLevel
+			}
+
+			if !logger.Enabled(ctx, level) {
+				return result, err
+			}
+
+			attrs := []slog.Attr{
+				slog.String("operation", call.Operation),
+				slog.String("method", call.Method),
+				slog.String("path", options.redactPath(call.Path)),
+				slog.Duration("latency", time.Since(start)),
+			}
+
+			if result != nil && len(result.Attempts) > 0 {
+				attrs = append(attrs,
+					slog.Int("status", result.Attempts[len(result.Attempts)-1].StatusCode),
+					slog.Int("attempts", len(result.Attempts)),
+				)
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+
+			if options.LogBodies {
+				if len(call.Header) > 0 {
+					attrs = append(attrs, slog.Any("request_headers", RedactHeaders(call.Header)))
+				}
+
+				if call.Body != nil {
+					attrs = append(attrs, slog.Any("request_body", options.redactBody(call.Body)))
+				}
+
+				if result != nil && result.Data != nil {
+					attrs = append(attrs, slog.Any("response_body", options.redactBody(result.Data)))
+				}
+			}
+
+			logger.LogAttrs(ctx, level, "edgegap call", attrs...)
+
+			return result, err
+		}
+	}
+}
+
+// RedactHeaders returns a copy of header, with the value of the secret headers (i.e. Authorization) redacted.
+func RedactHeaders(header http.Header) map[string]string {
+	values := make(map[string]string, len(header))
+
+	for key, value := range header {
+		if secretHeaders[http.CanonicalHeaderKey(key)] {
+			values[key] = redacted
+		} else {
+			values[key] = strings.Join(value, ", ")
+		}
+	}
+
+	return values
+}
+
+// RedactBody returns a JSON-like copy of body (a map, slice or scalar) with its secrets redacted :
+// tokens, passwords and the value of the hidden environment variables.
+func RedactBody(body interface{}) interface{} {
+	return LogOptions{}.redactBody(body)
+}
+
+func (o LogOptions) redactBody(body interface{}) interface{} {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return redacted
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return redacted
+	}
+
+	return o.redactValue("", value)
+}
+
+func (o LogOptions) redactValue(field string, value interface{}) interface{} {
+	field = strings.ToLower(field)
+
+	if secretFields[field] {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		hidden, _ := v["is_hidden"].(bool)
+
+		for key, item := range v {
+			if hidden && key == "value" {
+				v[key] = redacted
+				continue
+			}
+
+			v[key] = o.redactValue(key, item)
+		}
+
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = o.redactValue(field, item)
+		}
+
+		return v
+	case string:
+		if o.HashIPs && (ipFields[field] || field == "public_ip") && net.ParseIP(v) != nil {
+			return o.hashIP(v)
+		}
+	}
+
+	return value
+}
+
+// redactPath hashes the IPs found in the segments of path, i.e. /ip/{ip}/lookup.
+func (o LogOptions) redactPath(path string) string {
+	if !o.HashIPs {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		if net.ParseIP(segment) != nil {
+			segments[i] = o.hashIP(segment)
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (o LogOptions) hashIP(ip string) string {
+	sum := sha256.Sum256([]byte(o.HashSalt + ip))
+
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
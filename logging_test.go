@@ -0,0 +1,93 @@
+package edgegap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+
+	client := newStubClient(t, deploymentCreated, edgegap.WithLogging(
+		slog.New(slog.NewJSONHandler(&buf, nil)),
+		edgegap.LogOptions{Level: slog.LevelInfo, ErrorLevel: slog.LevelError, LogBodies: true, HashIPs: true},
+	))
+
+	_, err := client.DeploymentCreate(context.Background(), &edgegap.DeployementCreatePayload{
+		AppName:     "game",
+		VersionName: "v1",
+		IpList:      []string{"198.51.100.7"},
+		EnvVariables: []edgegap.EnvVariabls{
+			{Key: "API_KEY", Value: "s3cr3t", IsHidden: true},
+			{Key: "MODE", Value: "ranked"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DeploymentCreate() error = %v", err)
+	}
+
+	var record map[string]interface{}
+
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log record %q is not JSON : %v", buf.String(), err)
+	}
+
+	if record["operation"] != "DeploymentCreate" || record["status"] != float64(http.StatusOK) {
+		t.Errorf("record = %v, want the DeploymentCreate call with a 200 status", record)
+	}
+
+	logged := buf.String()
+
+	for _, secret := range []string{"s3cr3t", "198.51.100.7", "token test"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log record contains %q : %s", secret, logged)
+		}
+	}
+
+	if !strings.Contains(logged, "ranked") {
+		t.Errorf("log record misses the visible environment variable : %s", logged)
+	}
+}
+
+func TestLoggingErrorLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	client := newStubClient(t,
+		failing(1, http.StatusInternalServerError, nil, publicIP),
+		edgegap.WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))),
+	)
+
+	client.IPGet(context.Background())
+
+	if !strings.Contains(buf.String(), "level=ERROR") {
+		t.Errorf("failed call not logged at error level : %s", buf.String())
+	}
+
+	buf.Reset()
+
+	if _, err := client.IPGet(context.Background()); err != nil {
+		t.Fatalf("IPGet() error = %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("successful call logged below the handler level : %s", buf.String())
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := edgegap.RedactHeaders(http.Header{
+		"Authorization": []string{"token secret"},
+		"Accept":        []string{"application/json"},
+	})
+
+	if header["Authorization"] != "[REDACTED]" || header["Accept"] != "application/json" {
+		t.Errorf("RedactHeaders() = %v", header)
+	}
+}
@@ -0,0 +1,260 @@
+package edgegap
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LogEventType is the kind of a LogEvent.
+type LogEventType string
+
+const (
+	LogLine  = LogEventType("line")  // A new line of the container logs
+	LogCrash = LogEventType("crash") // A new crash of the container
+)
+
+// LogEvent is a new line or crash of the container of a deployment, sent by LogTail.
+type LogEvent struct {
+	Type      LogEventType
+	Line      string              // The line, without its line break. Set for LogLine
+	Crash     *ContainerCrashData // The crash data. Set for LogCrash
+	CrashKind CrashKind           // Classification of the crash, see ClassifyCrash. Set for LogCrash
+	CrashLogs string              // The logs of the crashed container, when there are. Set for LogCrash
+	Err       error               // Set on the last event when the tail failed
+}
+
+// LogTailOptions controls how LogTail polls the container logs.
+type LogTailOptions struct {
+	Interval time.Duration // Delay between two polls of the logs. Defaults to 2s
+}
+
+// Returns the logs, decoded according to their Encoding. Plain text and base64 encodings are supported.
+func (l *DeploymentContainerLogs) DecodedLogs() (string, error) {
+	switch strings.ToLower(strings.TrimSpace(l.Encoding)) {
+	case "", "utf-8", "utf8", "text", "plain":
+		return l.Logs, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(l.Logs)
+		if err != nil {
+			return "", fmt.Errorf("edgegap: decoding base64 logs : %w", err)
+		}
+
+		return string(decoded), nil
+	}
+
+	return "", fmt.Errorf("edgegap: unsupported logs encoding %q", l.Encoding)
+}
+
+// Follows the container logs of a deployment, polling them and sending an event for each new line and crash.
+// The channel is closed once the deployment is terminated or in error, after a last poll and a flush of the logs, or when
+// ctx is done. Logs not found before the deployment is running are polled again, the container not having started yet.
+// When the tail fails, the last event carries the error. The polling is controlled by the first opts, if any.
+func (e *EdgegapClient) LogTail(ctx context.Context, requestID string, opts ...LogTailOptions) <-chan LogEvent {
+	interval := 2 * time.Second
+	if len(opts) > 0 && opts[0].Interval > 0 {
+		interval = opts[0].Interval
+	}
+
+	events := make(chan LogEvent)
+
+	go func() {
+		defer close(events)
+
+		send := func(event LogEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		tail := &logTail{}
+
+		// Once the deployment ran or ended, its logs exist : a missing log means they are gone.
+		started := false
+
+		for {
+			res, err := e.Deployments.Logs(ctx, requestID)
+
+			switch {
+			case IsNotFound(err) && started:
+				// The logs of a terminated deployment are gone, what was seen of them is final.
+				tail.flush(send)
+				return
+			case IsNotFound(err):
+				// The container of a starting deployment has no logs yet.
+			case err != nil && !isTransient(err):
+				send(LogEvent{Err: err})
+				return
+			case err == nil:
+				if err := tail.update(res.Data, send); err != nil {
+					send(LogEvent{Err: err})
+					return
+				}
+			}
+
+			status, err := e.Deployments.Get(ctx, requestID)
+
+			switch {
+			case IsNotFound(err):
+				tail.flush(send)
+				return
+			case err != nil && !isTransient(err):
+				send(LogEvent{Err: err})
+				return
+			case err == nil:
+				if info := status.Data; info.Error || info.CurrentStatus == DeploymentStatusError || info.CurrentStatus == DeploymentStatusTerminated {
+					// The lines written since the last poll, before the container stopped, are only seen by a last poll.
+					if err := e.finalLogs(ctx, requestID, tail, send); err != nil {
+						send(LogEvent{Err: err})
+						return
+					}
+
+					tail.flush(send)
+					return
+				}
+
+				started = started || status.Data.Running
+			}
+
+			if err := sleepContext(ctx, interval); err != nil {
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// Follows the container logs of a deployment like LogTail, as a stream of lines. Crashes are not part of the stream.
+// Read returns io.EOF once the deployment is terminated, or the error of the tail. Close stops following the logs.
+func (e *EdgegapClient) LogReader(ctx context.Context, requestID string, opts ...LogTailOptions) io.ReadCloser {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &logReader{events: e.LogTail(ctx, requestID, opts...), cancel: cancel}
+}
+
+// finalLogs polls the logs of a deployment that ended one last time, sending what is new. Logs already gone are not an error.
+func (e *EdgegapClient) finalLogs(ctx context.Context, requestID string, tail *logTail, send func(LogEvent) bool) error {
+	res, err := e.Deployments.Logs(ctx, requestID)
+
+	switch {
+	case IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	return tail.update(res.Data, send)
+}
+
+// logTail keeps what was seen of the logs, to send only what is new.
+type logTail struct {
+	lines   []string // Complete lines of the last poll
+	partial string   // Last line of the last poll, not yet ended by a line break
+	crashes int      // Number of crashes already sent
+}
+
+// update sends the lines and crashes of logs that were not seen in the previous poll.
+func (t *logTail) update(logs *DeploymentContainerLogs, send func(LogEvent) bool) error {
+	text, err := logs.DecodedLogs()
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(text, "\n")
+	partial := lines[len(lines)-1]
+	lines = lines[:len(lines)-1]
+
+	for _, line := range lines[logOverlap(t.lines, lines):] {
+		if !send(LogEvent{Type: LogLine, Line: strings.TrimSuffix(line, "\r")}) {
+			return nil
+		}
+	}
+
+	t.lines = lines
+	t.partial = partial
+
+	// The crash data only grows while the deployment lives.
+	if t.crashes > len(logs.CrashData) {
+		t.crashes = 0
+	}
+
+	for i := t.crashes; i < len(logs.CrashData); i++ {
+		if !send(LogEvent{Type: LogCrash, Crash: &logs.CrashData[i], CrashKind: ClassifyCrash(logs.CrashData[i]).Kind, CrashLogs: logs.CrashLogs}) {
+			return nil
+		}
+	}
+
+	t.crashes = len(logs.CrashData)
+
+	return nil
+}
+
+// flush sends the last line, when it was not ended by a line break.
+func (t *logTail) flush(send func(LogEvent) bool) {
+	if t.partial != "" {
+		send(LogEvent{Type: LogLine, Line: strings.TrimSuffix(t.partial, "\r")})
+		t.partial = ""
+	}
+}
+
+// logOverlap returns the number of lines at the start of current already seen at the end of previous,
+// the API returning a window of the latest logs which moves as the container writes.
+func logOverlap(previous, current []string) int {
+	for k := min(len(previous), len(current)); k > 0; k-- {
+		if slices.Equal(previous[len(previous)-k:], current[:k]) {
+			return k
+		}
+	}
+
+	return 0
+}
+
+type logReader struct {
+	events <-chan LogEvent
+	cancel context.CancelFunc
+	buffer []byte
+	err    error
+}
+
+func (r *logReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		event, ok := <-r.events
+
+		switch {
+		case !ok:
+			r.err = io.EOF
+		case event.Err != nil:
+			r.err = event.Err
+		case event.Type == LogLine:
+			r.buffer = append(r.buffer, event.Line...)
+			r.buffer = append(r.buffer, '\n')
+		}
+	}
+
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+
+	return n, nil
+}
+
+func (r *logReader) Close() error {
+	r.cancel()
+
+	// Drain the tail so its goroutine ends.
+	for range r.events {
+	}
+
+	return nil
+}
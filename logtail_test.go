@@ -0,0 +1,194 @@
+package edgegap_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+var fastTail = edgegap.LogTailOptions{Interval: time.Millisecond}
+
+// nextLogEvent returns the next event of a tail, failing the test if none comes.
+func nextLogEvent(t *testing.T, events <-chan edgegap.LogEvent) (edgegap.LogEvent, bool) {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		return event, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("no log event received")
+		return edgegap.LogEvent{}, false
+	}
+}
+
+func TestLogTail(t *testing.T) {
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	server.SetContainerLogs(id, edgegap.DeploymentContainerLogs{Logs: "booting\nloading map\n"})
+
+	events := client.LogTail(context.Background(), id, fastTail)
+
+	for _, want := range []string{"booting", "loading map"} {
+		if event, _ := nextLogEvent(t, events); event.Type != edgegap.LogLine || event.Line != want {
+			t.Fatalf("event = %+v, want line %q", event, want)
+		}
+	}
+
+	// The API returns a moving window of the logs, base64 encoded here, and the last line is still being written.
+	server.SetContainerLogs(id, edgegap.DeploymentContainerLogs{
+		Logs:      base64.StdEncoding.EncodeToString([]byte("loading map\nready\nshutting do")),
+		Encoding:  "base64",
+		CrashLogs: "panic: nil map",
+		CrashData: []edgegap.ContainerCrashData{{ExitCode: 2, RestartCount: 1}},
+	})
+
+	if event, _ := nextLogEvent(t, events); event.Type != edgegap.LogLine || event.Line != "ready" {
+		t.Fatalf("event = %+v, want line %q", event, "ready")
+	}
+
+	if event, _ := nextLogEvent(t, events); event.Type != edgegap.LogCrash || event.Crash.ExitCode != 2 || event.CrashLogs != "panic: nil map" {
+		t.Fatalf("event = %+v, want the crash", event)
+	}
+
+	server.SetDeploymentStatus(id, edgegaptest.StatusTerminated)
+
+	// The unfinished line is flushed once the deployment is terminated.
+	if event, _ := nextLogEvent(t, events); event.Type != edgegap.LogLine || event.Line != "shutting do" {
+		t.Fatalf("event = %+v, want the flushed line", event)
+	}
+
+	if event, ok := nextLogEvent(t, events); ok {
+		t.Errorf("event = %+v after the flush, want the channel closed", event)
+	}
+}
+
+// tailedLines returns the lines sent by a tail until it closes, failing the test on an error event.
+func tailedLines(t *testing.T, events <-chan edgegap.LogEvent) []string {
+	t.Helper()
+
+	var lines []string
+
+	for {
+		event, ok := nextLogEvent(t, events)
+		if !ok {
+			return lines
+		}
+
+		if event.Err != nil {
+			t.Fatalf("tail error = %v", event.Err)
+		}
+
+		lines = append(lines, event.Line)
+	}
+}
+
+// logsAPI answers the logs and status polls of a tail with the next of logs and statuses, repeating the last ones.
+// An empty logs answers 404.
+func logsAPI(logs []string, statuses []string) http.HandlerFunc {
+	var logPolls, statusPolls atomic.Int64
+
+	next := func(values []string, polls *atomic.Int64) string {
+		return values[min(int(polls.Add(1)), len(values))-1]
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !strings.HasSuffix(r.URL.Path, "/container-logs") {
+			status := next(statuses, &statusPolls)
+			fmt.Fprintf(w, `{"request_id":"9f511e17dfa4","current_status":%q,"running":%t}`, status, status == edgegap.DeploymentStatusReady)
+			return
+		}
+
+		if text := next(logs, &logPolls); text != "" {
+			fmt.Fprintf(w, `{"logs":%q}`, text)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Deployment 9f511e17dfa4 not found"}`)
+	}
+}
+
+func TestLogTailBeforeStart(t *testing.T) {
+	client := newStubClient(t, logsAPI(
+		[]string{"", "", "booting\n"},
+		[]string{edgegap.DeploymentStatusDeploying, edgegap.DeploymentStatusDeploying, edgegap.DeploymentStatusReady, edgegap.DeploymentStatusTerminated},
+	))
+
+	lines := tailedLines(t, client.LogTail(context.Background(), "9f511e17dfa4", fastTail))
+
+	if !slices.Equal(lines, []string{"booting"}) {
+		t.Errorf("lines = %q, want the logs written once the container started", lines)
+	}
+}
+
+func TestLogTailLastPoll(t *testing.T) {
+	client := newStubClient(t, logsAPI(
+		[]string{"booting\n", "booting\nshutting down\n", ""},
+		[]string{edgegap.DeploymentStatusTerminated},
+	))
+
+	lines := tailedLines(t, client.LogTail(context.Background(), "9f511e17dfa4", fastTail))
+
+	if !slices.Equal(lines, []string{"booting", "shutting down"}) {
+		t.Errorf("lines = %q, want the lines written before the termination", lines)
+	}
+
+	// Running, then gone : the logs seen are final.
+	client = newStubClient(t, logsAPI(
+		[]string{"booting\nready", ""},
+		[]string{edgegap.DeploymentStatusReady},
+	))
+
+	lines = tailedLines(t, client.LogTail(context.Background(), "9f511e17dfa4", fastTail))
+
+	if !slices.Equal(lines, []string{"booting", "ready"}) {
+		t.Errorf("lines = %q, want the logs seen while running", lines)
+	}
+}
+
+func TestLogReader(t *testing.T) {
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	server.SetContainerLogs(id, edgegap.DeploymentContainerLogs{Logs: "a\nb\n"})
+	server.SetDeploymentStatus(id, edgegaptest.StatusError)
+
+	reader := client.LogReader(context.Background(), id, fastTail)
+	defer reader.Close()
+
+	logs, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if string(logs) != "a\nb\n" {
+		t.Errorf("ReadAll() = %q, want %q", logs, "a\nb\n")
+	}
+}
+
+func TestDecodedLogs(t *testing.T) {
+	logs := edgegap.DeploymentContainerLogs{Logs: "bG9ncw==", Encoding: "base64"}
+
+	if decoded, err := logs.DecodedLogs(); err != nil || decoded != "logs" {
+		t.Errorf("DecodedLogs() = %q, %v, want %q", decoded, err, "logs")
+	}
+
+	logs.Encoding = "zstd"
+
+	if _, err := logs.DecodedLogs(); err == nil {
+		t.Error("DecodedLogs() with an unknown encoding succeeded")
+	}
+}
@@ -5,9 +5,9 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"net/http"
 )
 
 type Matchmaker struct {
@@ -111,84 +111,143 @@ type MatchmakerComponentListRes struct {
 	Data  []MatchmakerComponent `json:"data"`
 }
 
+// MatchmakerService manages the matchmakers, see EdgegapClient.Matchmaker.
+type MatchmakerService struct {
+	client *EdgegapClient
+
+	Components      *MatchmakerComponentsService      // Components the matchmakers are made of
+	Releases        *MatchmakerReleasesService        // Releases of the matchmakers
+	ManagedReleases *MatchmakerManagedReleasesService // Managed releases of the matchmakers
+	ReleaseConfigs  *MatchmakerReleaseConfigsService  // Configurations used by the managed releases
+}
+
+// MatchmakerComponentsService manages the matchmaker components, see MatchmakerService.Components.
+type MatchmakerComponentsService struct {
+	client *EdgegapClient
+
+	Envs *MatchmakerEnvsService // Environment variables of the components
+}
+
+// MatchmakerEnvsService manages the environment variables of the matchmaker components, see MatchmakerComponentsService.Envs.
+type MatchmakerEnvsService struct {
+	client *EdgegapClient
+}
+
+// MatchmakerReleasesService manages the matchmaker releases, see MatchmakerService.Releases.
+type MatchmakerReleasesService struct {
+	client *EdgegapClient
+}
+
+// MatchmakerManagedReleasesService manages the matchmaker managed releases, see MatchmakerService.ManagedReleases.
+type MatchmakerManagedReleasesService struct {
+	client *EdgegapClient
+}
+
+// MatchmakerReleaseConfigsService manages the matchmaker release configurations, see MatchmakerService.ReleaseConfigs.
+type MatchmakerReleaseConfigsService struct {
+	client *EdgegapClient
+}
+
 // Create a new matchmaker component.
-func (e *EdgegapClient) MatchmakerCreateComponent(component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error) {
+func (s *MatchmakerComponentsService) Create(ctx context.Context, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error) {
 	var response MatchmakerComponent
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(component).Post("/aom/component")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerCreateComponent",
+		method:    http.MethodPost,
+		path:      "/aom/component",
+		body:      component,
 	}, &response)
 }
 
 // Update a matchmaker component with new specifications.
-func (e *EdgegapClient) MatchmakerUpdateComponent(name string, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error) {
+func (s *MatchmakerComponentsService) Update(ctx context.Context, name string, component MatchmakerComponentCreate) (*Response[MatchmakerComponent], error) {
 	var response MatchmakerComponent
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(component).Patch(fmt.Sprintf("/aom/component/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerUpdateComponent",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/aom/component/%s", name),
+		body:      component,
 	}, &response)
 }
 
 // Delete a matchmaker component. It will not delete the matchmaker.
-func (e *EdgegapClient) MatchmakerDeleteComponent(name string) (*Response[map[string]string], error) {
+func (s *MatchmakerComponentsService) Delete(ctx context.Context, name string) (*Response[map[string]string], error) {
 	var response map[string]string
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/aom/component/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerDeleteComponent",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/aom/component/%s", name),
 	}, &response)
 }
 
 // Retrieve a matchmaker component.
-func (e *EdgegapClient) MatchmakerGetComponent(name string) (*Response[MatchmakerComponent], error) {
+func (s *MatchmakerComponentsService) Get(ctx context.Context, name string) (*Response[MatchmakerComponent], error) {
 	var response MatchmakerComponent
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/component/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerGetComponent",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/component/%s", name),
 	}, &response)
 }
 
 // Create a new matchmaker component ENV.
-func (e *EdgegapClient) MatchmakerComponentAddEnv(name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error) {
+func (s *MatchmakerEnvsService) Create(ctx context.Context, name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error) {
 	var response MatchmakerEnvRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(env).Post(fmt.Sprintf("/aom/component/%s/env", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerComponentAddEnv",
+		method:    http.MethodPost,
+		path:      fmt.Sprintf("/aom/component/%s/env", name),
+		body:      env,
 	}, &response)
 }
 
 // Update a matchmaker component ENV.
-func (e *EdgegapClient) MatchmakerComponentUpdateEnv(name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error) {
+func (s *MatchmakerEnvsService) Update(ctx context.Context, name string, env MatchmakerEnv) (*Response[MatchmakerEnvRes], error) {
 	var response MatchmakerEnvRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(env).Patch(fmt.Sprintf("/aom/component/%s/env/%s", name, env.Key))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerComponentUpdateEnv",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/aom/component/%s/env/%s", name, env.Key),
+		body:      env,
 	}, &response)
 }
 
 // Delete a matchmaker component ENV. It will not delete the component or the matchmaker.
-func (e *EdgegapClient) MatchmakerComponentDeleteEnv(name string, env string) (*Response[map[string]string], error) {
+func (s *MatchmakerEnvsService) Delete(ctx context.Context, name string, env string) (*Response[map[string]string], error) {
 	var response map[string]string
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/aom/component/%s/env/%s", name, env))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerComponentDeleteEnv",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/aom/component/%s/env/%s", name, env),
 	}, &response)
 }
 
 // Retrieve a matchmaker component ENV.
-func (e *EdgegapClient) MatchmakeComponentGetEnv(name string, env string) (*Response[MatchmakerEnvRes], error) {
+func (s *MatchmakerEnvsService) Get(ctx context.Context, name string, env string) (*Response[MatchmakerEnvRes], error) {
 	var response MatchmakerEnvRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/component/%s/env/%s", name, env))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakeComponentGetEnv",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/component/%s/env/%s", name, env),
 	}, &response)
 }
 
 // List all ENVs for a specific matchmaker component.
-func (e *EdgegapClient) MatchmakerComponentListEnv(name string) (*Response[MatchmakerEnvListRes], error) {
+func (s *MatchmakerEnvsService) List(ctx context.Context, name string) (*Response[MatchmakerEnvListRes], error) {
 	var response MatchmakerEnvListRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/component/%s/envs", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerComponentListEnv",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/component/%s/envs", name),
 	}, &response)
 }
 
@@ -196,184 +255,233 @@ func (e *EdgegapClient) MatchmakerComponentListEnv(name string) (*Response[Match
 List all components for a specific matchmaker.
 API Reference : https://docs.edgegap.com/api/#tag/Matchmaker/operation/get-component-list
 */
-func (e *EdgegapClient) MatchmakerComponentList() (*Response[MatchmakerComponentListRes], error) {
+func (s *MatchmakerComponentsService) List(ctx context.Context) (*Response[MatchmakerComponentListRes], error) {
 	var response MatchmakerComponentListRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/aom/components")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerComponentList",
+		method:    http.MethodGet,
+		path:      "/aom/components",
 	}, &response)
 }
 
 // Create a new matchmaker. A matchmaker is a top-level object; you must create child resources to work properly.
-func (e *EdgegapClient) MatchmakerCreate(name string) (*Response[Matchmaker], error) {
+func (s *MatchmakerService) Create(ctx context.Context, name string) (*Response[Matchmaker], error) {
 	var response Matchmaker
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string]string{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerCreate",
+		method:    http.MethodPost,
+		path:      "/aom/matchmaker",
+		body: map[string]string{
 			"name": name,
-		}).Post("/aom/matchmaker")
+		},
 	}, &response)
 }
 
 // Update a matchmaker with new specifications.
-func (e *EdgegapClient) MatchmakerUpdate(name string, newName string) (*Response[Matchmaker], error) {
+func (s *MatchmakerService) Update(ctx context.Context, name string, newName string) (*Response[Matchmaker], error) {
 	var response Matchmaker
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string]string{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerUpdate",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/aom/matchmaker/%s", name),
+		body: map[string]string{
 			"name": newName,
-		}).Patch(fmt.Sprintf("/aom/matchmaker/%s", name))
+		},
 	}, &response)
 }
 
 // Delete a matchmaker.
-func (e *EdgegapClient) MatchmakerDelete(name string) (*Response[interface{}], error) {
+func (s *MatchmakerService) Delete(ctx context.Context, name string) (*Response[interface{}], error) {
 	var response interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/aom/matchmaker/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerDelete",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/aom/matchmaker/%s", name),
 	}, &response)
 }
 
 // Retrieve a matchmaker.
-func (e *EdgegapClient) MatchmakerGet(name string) (*Response[Matchmaker], error) {
+func (s *MatchmakerService) Get(ctx context.Context, name string) (*Response[Matchmaker], error) {
 	var response Matchmaker
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/matchmaker/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerGet",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/matchmaker/%s", name),
 	}, &response)
 }
 
-func (e *EdgegapClient) MatchmakerList() (*Response[MatchmakerListRes], error) {
+// List all the matchmakers you own.
+func (s *MatchmakerService) List(ctx context.Context) (*Response[MatchmakerListRes], error) {
 	var response MatchmakerListRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/aom/matchmakers")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerList",
+		method:    http.MethodGet,
+		path:      "/aom/matchmakers",
 	}, &response)
 }
 
 // Create a matchmaker release.
-func (e *EdgegapClient) MatchmakerCreateRelease(name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error) {
+func (s *MatchmakerReleasesService) Create(ctx context.Context, name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error) {
 	var response MatchmakerRelease
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Post(fmt.Sprintf("/aom/matchmaker/%s/release", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerCreateRelease",
+		method:    http.MethodPost,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release", name),
+		body:      payload,
 	}, &response)
 }
 
 // Update a matchmaker release.
-func (e *EdgegapClient) MatchmakerUpdateRelease(name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error) {
+func (s *MatchmakerReleasesService) Update(ctx context.Context, name string, payload MatchmakerReleaseCreate) (*Response[MatchmakerRelease], error) {
 	var response MatchmakerRelease
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Patch(fmt.Sprintf("/aom/matchmaker/%s/release", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerUpdateRelease",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/%s", name, payload.Version),
+		body:      payload,
 	}, &response)
 }
 
 // Delete a matchmaker release.
-func (e *EdgegapClient) MatchmakerDeleteRelease(name string, version string) (*Response[interface{}], error) {
+func (s *MatchmakerReleasesService) Delete(ctx context.Context, name string, version string) (*Response[interface{}], error) {
 	var response interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/aom/matchmaker/%s/release/%s", name, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerDeleteRelease",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/%s", name, version),
 	}, &response)
 }
 
 // Retrieve a matchmaker release.
-func (e *EdgegapClient) MatchmakerGetRelease(name string, version string) (*Response[MatchmakerRelease], error) {
+func (s *MatchmakerReleasesService) Get(ctx context.Context, name string, version string) (*Response[MatchmakerRelease], error) {
 	var response MatchmakerRelease
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/matchmaker/%s/release/%s", name, version))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerGetRelease",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/%s", name, version),
 	}, &response)
 }
 
 // List all releases of a specific matchmaker.
-func (e *EdgegapClient) MatchmakerListRelease(name string) (*Response[MatchmakerReleaseListRes], error) {
+func (s *MatchmakerReleasesService) List(ctx context.Context, name string) (*Response[MatchmakerReleaseListRes], error) {
 	var response MatchmakerReleaseListRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/matchmaker/%s/release", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerListRelease",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release", name),
 	}, &response)
 }
 
 // Update a matchmaker managed release.
-func (e *EdgegapClient) MatchmakerCreateManagedRelease(name string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error) {
+func (s *MatchmakerManagedReleasesService) Create(ctx context.Context, name string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error) {
 	var response MatchmakerManagedRelease
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Post(fmt.Sprintf("/aom/matchmaker/%s/release/managed", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerCreateManagedRelease",
+		method:    http.MethodPost,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/managed", name),
+		body:      payload,
 	}, &response)
 }
 
 // Update a matchmaker managed release.
-func (e *EdgegapClient) MatchmakerUpdateManagedRelease(name string, releaseVersion string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error) {
+func (s *MatchmakerManagedReleasesService) Update(ctx context.Context, name string, releaseVersion string, payload MatchmakerManagedReleaseCreate) (*Response[MatchmakerManagedRelease], error) {
 	var response MatchmakerManagedRelease
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Patch(fmt.Sprintf("/aom/matchmaker/%s/release/managed/%s", name, releaseVersion))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerUpdateManagedRelease",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/managed/%s", name, releaseVersion),
+		body:      payload,
 	}, &response)
 }
 
 // Delete a matchmaker managed release. It will not delete the matchmaker.
-func (e *EdgegapClient) MatchmakerDeleteManagedRelease(name string, releaseVersion string) (*Response[interface{}], error) {
+func (s *MatchmakerManagedReleasesService) Delete(ctx context.Context, name string, releaseVersion string) (*Response[interface{}], error) {
 	var response interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/aom/matchmaker/%s/release/managed/%s", name, releaseVersion))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerDeleteManagedRelease",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/managed/%s", name, releaseVersion),
 	}, &response)
 }
 
 // Retrieve a matchmaker managed release.
-func (e *EdgegapClient) MatchmakerGetManagedRelease(name string, releaseVersion string) (*Response[MatchmakerManagedRelease], error) {
+func (s *MatchmakerManagedReleasesService) Get(ctx context.Context, name string, releaseVersion string) (*Response[MatchmakerManagedRelease], error) {
 	var response MatchmakerManagedRelease
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/matchmaker/%s/release/managed/%s", name, releaseVersion))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerGetManagedRelease",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/matchmaker/%s/release/managed/%s", name, releaseVersion),
 	}, &response)
 }
 
 // Create a matchmaker release config.
-func (e *EdgegapClient) MatchmakerCreateReleaseConfig(payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error) {
+func (s *MatchmakerReleaseConfigsService) Create(ctx context.Context, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error) {
 	var response MatchmakerReleaseConfig
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/aom/release/config")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerCreateReleaseConfig",
+		method:    http.MethodPost,
+		path:      "/aom/release/config",
+		body:      payload,
 	}, &response)
 }
 
 // Update a matchmaker release config.
-func (e *EdgegapClient) MatchmakerUpdateReleaseConfig(name string, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error) {
+func (s *MatchmakerReleaseConfigsService) Update(ctx context.Context, name string, payload MatchmakerReleaseConfig) (*Response[MatchmakerReleaseConfig], error) {
 	var response MatchmakerReleaseConfig
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/release/config/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerUpdateReleaseConfig",
+		method:    http.MethodPatch,
+		path:      fmt.Sprintf("/aom/release/config/%s", name),
+		body:      payload,
 	}, &response)
 }
 
 // Delete a matchmaker release config.
-func (e *EdgegapClient) MatchmakerDeleteReleaseConfig(name string) (*Response[interface{}], error) {
+func (s *MatchmakerReleaseConfigsService) Delete(ctx context.Context, name string) (*Response[interface{}], error) {
 	var response interface{}
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/aom/release/config/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerDeleteReleaseConfig",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/aom/release/config/%s", name),
 	}, &response)
 }
 
 // Get a matchmaker release config.
-func (e *EdgegapClient) MatchmakerGetReleaseConfig(name string) (*Response[MatchmakerReleaseConfig], error) {
+func (s *MatchmakerReleaseConfigsService) Get(ctx context.Context, name string) (*Response[MatchmakerReleaseConfig], error) {
 	var response MatchmakerReleaseConfig
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/aom/release/config/%s", name))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerGetReleaseConfig",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/aom/release/config/%s", name),
 	}, &response)
 }
 
 // List all configs for a specific matchmaker release.
-func (e *EdgegapClient) MatchmakerListReleaseConfig() (*Response[MatchmakerReleaseConfigListRes], error) {
+func (s *MatchmakerReleaseConfigsService) List(ctx context.Context) (*Response[MatchmakerReleaseConfigListRes], error) {
 	var respoonse MatchmakerReleaseConfigListRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/aom/release/config")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MatchmakerListReleaseConfig",
+		method:    http.MethodGet,
+		path:      "/aom/release/config",
 	}, &respoonse)
 }
@@ -0,0 +1,124 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestMatchmakerComponents(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	component := edgegap.MatchmakerComponentCreate{Name: "frontend", Repo: "registry.edgegap.com", Image: "studio/frontend", Tag: "1.0.0"}
+
+	if _, err := client.MatchmakerCreateComponent(ctx, component); err != nil {
+		t.Fatalf("MatchmakerCreateComponent() error = %v", err)
+	}
+
+	if _, err := client.MatchmakerComponentAddEnv(ctx, "frontend", edgegap.MatchmakerEnv{Key: "MODE", Value: "ranked"}); err != nil {
+		t.Fatalf("MatchmakerComponentAddEnv() error = %v", err)
+	}
+
+	if _, err := client.MatchmakerComponentUpdateEnv(ctx, "frontend", edgegap.MatchmakerEnv{Key: "MODE", Value: "casual"}); err != nil {
+		t.Fatalf("MatchmakerComponentUpdateEnv() error = %v", err)
+	}
+
+	env, err := client.MatchmakeComponentGetEnv(ctx, "frontend", "MODE")
+	if err != nil {
+		t.Fatalf("MatchmakeComponentGetEnv() error = %v", err)
+	}
+
+	if env.Data.Value != "casual" {
+		t.Errorf("env value = %q, want %q", env.Data.Value, "casual")
+	}
+
+	if _, err := client.MatchmakerComponentDeleteEnv(ctx, "frontend", "MODE"); err != nil {
+		t.Fatalf("MatchmakerComponentDeleteEnv() error = %v", err)
+	}
+
+	envs, err := client.MatchmakerComponentListEnv(ctx, "frontend")
+	if err != nil {
+		t.Fatalf("MatchmakerComponentListEnv() error = %v", err)
+	}
+
+	if envs.Data.Count != 0 {
+		t.Errorf("env count = %d, want 0", envs.Data.Count)
+	}
+
+	list, err := client.MatchmakerComponentList(ctx)
+	if err != nil {
+		t.Fatalf("MatchmakerComponentList() error = %v", err)
+	}
+
+	if list.Data.Count != 1 || list.Data.Data[0].Image != "studio/frontend" {
+		t.Errorf("MatchmakerComponentList() = %+v, want the frontend component", list.Data)
+	}
+}
+
+func TestMatchmakerReleases(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	if _, err := client.MatchmakerCreate(ctx, "arena"); err != nil {
+		t.Fatalf("MatchmakerCreate() error = %v", err)
+	}
+
+	for _, name := range []string{"frontend", "director", "director-v2", "mmf"} {
+		if _, err := client.MatchmakerCreateComponent(ctx, edgegap.MatchmakerComponentCreate{Name: name, Image: "studio/" + name}); err != nil {
+			t.Fatalf("MatchmakerCreateComponent() error = %v", err)
+		}
+	}
+
+	release := edgegap.MatchmakerReleaseCreate{
+		Version:                    "1.0.0",
+		FrontendComponentName:      "frontend",
+		DirectorComponentName:      "director",
+		MatchFunctionComponentName: "mmf",
+	}
+
+	if _, err := client.MatchmakerCreateRelease(ctx, "arena", release); err != nil {
+		t.Fatalf("MatchmakerCreateRelease() error = %v", err)
+	}
+
+	release.DirectorComponentName = "director-v2"
+
+	if _, err := client.MatchmakerUpdateRelease(ctx, "arena", release); err != nil {
+		t.Fatalf("MatchmakerUpdateRelease() error = %v", err)
+	}
+
+	got, err := client.MatchmakerGetRelease(ctx, "arena", "1.0.0")
+	if err != nil {
+		t.Fatalf("MatchmakerGetRelease() error = %v", err)
+	}
+
+	if got.Data.DirectorComponentName != "director-v2" {
+		t.Errorf("DirectorComponentName = %q, want %q", got.Data.DirectorComponentName, "director-v2")
+	}
+
+	if _, err := client.MatchmakerCreateReleaseConfig(ctx, edgegap.MatchmakerReleaseConfig{Name: "default", Configuration: "{}"}); err != nil {
+		t.Fatalf("MatchmakerCreateReleaseConfig() error = %v", err)
+	}
+
+	if _, err := client.MatchmakerUpdateReleaseConfig(ctx, "default", edgegap.MatchmakerReleaseConfig{Configuration: `{"teams":2}`}); err != nil {
+		t.Fatalf("MatchmakerUpdateReleaseConfig() error = %v", err)
+	}
+
+	config, err := client.MatchmakerGetReleaseConfig(ctx, "default")
+	if err != nil {
+		t.Fatalf("MatchmakerGetReleaseConfig() error = %v", err)
+	}
+
+	if config.Data.Configuration != `{"teams":2}` {
+		t.Errorf("Configuration = %q, want %q", config.Data.Configuration, `{"teams":2}`)
+	}
+
+	if _, err := client.MatchmakerDelete(ctx, "arena"); err != nil {
+		t.Fatalf("MatchmakerDelete() error = %v", err)
+	}
+
+	if _, err := client.MatchmakerListRelease(ctx, "arena"); !edgegap.IsNotFound(err) {
+		t.Errorf("MatchmakerListRelease() after delete error = %v, want not found", err)
+	}
+}
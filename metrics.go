@@ -5,10 +5,10 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"time"
-
-	"github.com/go-resty/resty/v2"
 )
 
 type Steps string
@@ -52,8 +52,13 @@ type Metrics struct {
 	Network MetricsNetworkModel `json:"network"`
 }
 
+// MetricsService reads the metrics of the deployments, see EdgegapClient.Metrics.
+type MetricsService struct {
+	client *EdgegapClient
+}
+
 // Get the metrics for a specific deployment based on the start_time, end_time and steps. raw parameter can be set to true to get the raw data.
-func (e *EdgegapClient) MetricsByDeploymentID(id string, filter MetricsFilter) (*Response[Metrics], error) {
+func (s *MetricsService) Get(ctx context.Context, id string, filter MetricsFilter) (*Response[Metrics], error) {
 	query := "?"
 
 	timeFmtString := "2006-01-02 15:04:05.000000"
@@ -86,7 +91,10 @@ func (e *EdgegapClient) MetricsByDeploymentID(id string, filter MetricsFilter) (
 
 	var response Metrics
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/metrics/deployment/%s%s", id, query))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "MetricsByDeploymentID",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/metrics/deployment/%s%s", id, query),
+		params:    map[string]string{paramRequestID: id},
 	}, &response)
 }
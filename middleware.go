@@ -0,0 +1,57 @@
+package edgegap
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Call describes an operation of the client going through the middleware chain.
+type Call struct {
+	Operation string            // Name of the client method serving the call (i.e. DeploymentCreate)
+	Method    string            // HTTP method
+	Path      string            // Endpoint, relative to the API version (i.e. /status/{request_id})
+	Params    map[string]string // Identifiers targeted by the call, keyed by app_name, app_version, request_id or session_id
+	Body      interface{}       // Request body, nil when there is none
+	Header    http.Header       // Extra headers sent with the request, overriding the client ones
+
+	target interface{}
+}
+
+// Result is the outcome of a Call.
+type Result struct {
+	Response *resty.Response // Raw response of the last attempt, nil when no request was sent
+	Data     interface{}     // Pointer to the decoded response (i.e. *DeploymentInfo), nil when the call failed
+	Attempts []Attempt       // Every HTTP attempt made to serve the call
+}
+
+// RoundTrip serves a Call. The innermost RoundTrip sends it to the API, handling rate limits and retries.
+type RoundTrip func(ctx context.Context, call *Call) (*Result, error)
+
+// Middleware wraps a RoundTrip to run code around every call made by the client.
+type Middleware func(next RoundTrip) RoundTrip
+
+// Adds middlewares to every call made by the client. The first middleware registered is the outermost one.
+// Use must not be called while requests are in flight.
+func (e *EdgegapClient) Use(middlewares ...Middleware) {
+	e.middlewares = append(e.middlewares, middlewares...)
+}
+
+// Adds middlewares to the client, same as calling EdgegapClient.Use.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// chain wraps the round trip sending calls to the API with the registered middlewares.
+func (e *EdgegapClient) chain() RoundTrip {
+	next := RoundTrip(e.roundTrip)
+
+	for i := len(e.middlewares) - 1; i >= 0; i-- {
+		next = e.middlewares[i](next)
+	}
+
+	return next
+}
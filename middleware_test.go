@@ -0,0 +1,98 @@
+package edgegap_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var order []string
+
+	record := func(name string) edgegap.Middleware {
+		return func(next edgegap.RoundTrip) edgegap.RoundTrip {
+			return func(ctx context.Context, call *edgegap.Call) (*edgegap.Result, error) {
+				order = append(order, name+" before")
+				result, err := next(ctx, call)
+				order = append(order, name+" after")
+
+				return result, err
+			}
+		}
+	}
+
+	client := newStubClient(t, publicIP, edgegap.WithMiddleware(record("first")))
+	client.Use(record("second"))
+
+	if _, err := client.IPGet(context.Background()); err != nil {
+		t.Fatalf("IPGet() error = %v", err)
+	}
+
+	want := []string{"first before", "second before", "second after", "first after"}
+
+	if !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestMiddlewareSeesCall(t *testing.T) {
+	var seen *edgegap.Call
+	var result *edgegap.Result
+	var header string
+
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Test")
+		publicIP(w, r)
+	}, edgegap.WithMiddleware(func(next edgegap.RoundTrip) edgegap.RoundTrip {
+		return func(ctx context.Context, call *edgegap.Call) (*edgegap.Result, error) {
+			seen = call
+			call.Header = http.Header{"X-Test": []string{"1"}}
+
+			res, err := next(ctx, call)
+			result = res
+
+			return res, err
+		}
+	}))
+
+	if _, err := client.DeploymentGetStatus(context.Background(), "9f511e17dfa4"); err != nil {
+		t.Fatalf("DeploymentGetStatus() error = %v", err)
+	}
+
+	if seen.Operation != "DeploymentGetStatus" || seen.Method != http.MethodGet || seen.Path != "/status/9f511e17dfa4" {
+		t.Errorf("Call = %+v, want the DeploymentGetStatus call", seen)
+	}
+
+	if header != "1" {
+		t.Errorf("X-Test header = %q, want the one set by the middleware", header)
+	}
+
+	if _, ok := result.Data.(*edgegap.DeploymentInfo); !ok || len(result.Attempts) != 1 {
+		t.Errorf("Result = %+v, want the decoded deployment after a single attempt", result)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	var requests atomic.Int64
+
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		publicIP(w, r)
+	}, edgegap.WithMiddleware(func(next edgegap.RoundTrip) edgegap.RoundTrip {
+		return func(ctx context.Context, call *edgegap.Call) (*edgegap.Result, error) {
+			return nil, context.Canceled
+		}
+	}))
+
+	if _, err := client.IPGet(context.Background()); err != context.Canceled {
+		t.Fatalf("IPGet() error = %v, want the middleware error", err)
+	}
+
+	if requests.Load() != 0 {
+		t.Errorf("%d requests reached the API, want none", requests.Load())
+	}
+}
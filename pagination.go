@@ -0,0 +1,43 @@
+package edgegap
+
+import (
+	"context"
+	"iter"
+)
+
+// Page size used by the iterators walking every page of a list endpoint.
+const DefaultPageSize = 100
+
+// paginate walks every page returned by fetch, starting at the first one, until the API reports there is no next page.
+// The iteration stops at the first error, which is yielded with the zero value of T.
+func paginate[T any](ctx context.Context, fetch func(ctx context.Context, params PaginationParams) ([]T, Pagination, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		params := PaginationParams{Page: 1, Size: DefaultPageSize}
+
+		for {
+			items, pagination, err := fetch(ctx, params)
+
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !pagination.HasNext || len(items) == 0 {
+				return
+			}
+
+			if pagination.NextPageNumber > params.Page {
+				params.Page = pagination.NextPageNumber
+			} else {
+				params.Page++
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package edgegap_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// pagedList serves count items over pages of the requested size, listed under key with the ID field idField.
+// The page failing, when not 0, is answered with a server error. The pages fetched are counted in fetched.
+func pagedList(t *testing.T, key, idField string, count, failing int, fetched *atomic.Int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		if page < 1 || limit < 1 {
+			t.Errorf("page %q of size %q requested, want positive ones", r.URL.Query().Get("page"), r.URL.Query().Get("limit"))
+		}
+
+		fetched.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if page == failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"Internal Server Error"}`)
+			return
+		}
+
+		var items []map[string]string
+
+		for i := (page - 1) * limit; i < min(page*limit, count); i++ {
+			items = append(items, map[string]string{idField: fmt.Sprintf("item-%d", i)})
+		}
+
+		pagination := edgegap.Pagination{Number: page, HasNext: page*limit < count, HasPrevious: page > 1}
+		if pagination.HasNext {
+			pagination.NextPageNumber = page + 1
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{key: items, "pagination": pagination})
+	}
+}
+
+// collect returns the IDs yielded by seq, stopping after max of them when max is not 0, with the first error.
+func collect[T any](seq iter.Seq2[T, error], id func(T) string, max int) ([]string, error) {
+	var ids []string
+
+	for item, err := range seq {
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id(item))
+
+		if len(ids) == max {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+func itemIDs(from, to int) []string {
+	var ids []string
+
+	for i := from; i < to; i++ {
+		ids = append(ids, fmt.Sprintf("item-%d", i))
+	}
+
+	return ids
+}
+
+func TestIterateWalksEveryPage(t *testing.T) {
+	const count = 2*edgegap.DefaultPageSize + 5
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		key, id string
+		iterate func(client *edgegap.EdgegapClient) ([]string, error)
+	}{
+		{"deployments", "data", "request_id", func(client *edgegap.EdgegapClient) ([]string, error) {
+			return collect(client.DeploymentIterate(ctx), func(d edgegap.Deployment) string { return d.RequestID }, 0)
+		}},
+		{"sessions", "data", "session_id", func(client *edgegap.EdgegapClient) ([]string, error) {
+			return collect(client.SessionIterate(ctx), func(s edgegap.Session) string { return s.ID }, 0)
+		}},
+		{"fleets", "fleets", "name", func(client *edgegap.EdgegapClient) ([]string, error) {
+			return collect(client.FleetIterate(ctx), func(f edgegap.Fleet) string { return f.Name }, 0)
+		}},
+	}
+
+	for _, test := range tests {
+		var fetched atomic.Int64
+
+		client := newStubClient(t, pagedList(t, test.key, test.id, count, 0, &fetched))
+
+		ids, err := test.iterate(client)
+		if err != nil {
+			t.Fatalf("%s : iteration error = %v", test.name, err)
+		}
+
+		if !slices.Equal(ids, itemIDs(0, count)) || fetched.Load() != 3 {
+			t.Errorf("%s : %d items yielded from %d pages, want %d from 3 pages", test.name, len(ids), fetched.Load(), count)
+		}
+	}
+}
+
+func TestIterateStopsOnBreak(t *testing.T) {
+	var fetched atomic.Int64
+
+	client := newStubClient(t, pagedList(t, "data", "request_id", 3*edgegap.DefaultPageSize, 0, &fetched))
+
+	ids, err := collect(client.DeploymentIterate(context.Background()), func(d edgegap.Deployment) string { return d.RequestID }, edgegap.DefaultPageSize+1)
+	if err != nil {
+		t.Fatalf("DeploymentIterate() error = %v", err)
+	}
+
+	// Breaking in the second page must not fetch the third one.
+	if !slices.Equal(ids, itemIDs(0, edgegap.DefaultPageSize+1)) || fetched.Load() != 2 {
+		t.Errorf("%d deployments yielded from %d pages, want %d from 2 pages", len(ids), fetched.Load(), edgegap.DefaultPageSize+1)
+	}
+}
+
+func TestIterateStopsOnError(t *testing.T) {
+	var fetched atomic.Int64
+
+	client := newStubClient(t, pagedList(t, "data", "session_id", 3*edgegap.DefaultPageSize, 2, &fetched))
+
+	ids, err := collect(client.SessionIterate(context.Background()), func(s edgegap.Session) string { return s.ID }, 0)
+
+	var apiErr *edgegap.APIError
+
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("SessionIterate() error = %v, want the error of the second page", err)
+	}
+
+	if !slices.Equal(ids, itemIDs(0, edgegap.DefaultPageSize)) || fetched.Load() != 2 {
+		t.Errorf("%d sessions yielded from %d pages before the error, want the %d of the first page", len(ids), fetched.Load(), edgegap.DefaultPageSize)
+	}
+}
+
+func TestListPage(t *testing.T) {
+	var fetched atomic.Int64
+
+	client := newStubClient(t, pagedList(t, "fleets", "name", 25, 0, &fetched))
+
+	res, err := client.FleetListPage(context.Background(), edgegap.PaginationParams{Page: 3, Size: 10})
+	if err != nil {
+		t.Fatalf("FleetListPage() error = %v", err)
+	}
+
+	if len(res.Data.Fleets) != 5 || res.Data.Fleets[0].Name != "item-20" || res.Data.Pagination.HasNext {
+		t.Errorf("FleetListPage() = %+v, want the last 5 fleets", res.Data)
+	}
+}
@@ -0,0 +1,262 @@
+package edgegap
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// EndpointGroup regroups endpoints sharing the same rate limit.
+type EndpointGroup string
+
+const (
+	GroupDeployments = EndpointGroup("deployments")
+	GroupSessions    = EndpointGroup("sessions")
+	GroupMatchmaker  = EndpointGroup("matchmaker")
+	GroupTelemetry   = EndpointGroup("telemetry")
+	GroupOther       = EndpointGroup("other") // Every endpoint not part of another group
+)
+
+// RateLimit is the steady rate and the burst allowed by a token bucket.
+type RateLimit struct {
+	Rate  float64 // Requests per second
+	Burst int     // Requests that can be made at once when the bucket is full. Defaults to 1
+}
+
+// RateLimiter makes callers wait until a request can be sent without going over the configured limits.
+// A single RateLimiter can be shared by several clients and is safe for concurrent use.
+//
+// When the API answers with 429 Too Many Requests, the rate of the bucket used by the request is halved
+// (and paused for the Retry-After duration, if any), then recovers progressively on each successful request.
+type RateLimiter struct {
+	global *tokenBucket
+	groups map[EndpointGroup]*tokenBucket
+}
+
+// Creates a rate limiter with a global limit applying to every request and optional limits per endpoint group.
+// A zero global RateLimit means that only the group limits apply.
+func NewRateLimiter(global RateLimit, groups map[EndpointGroup]RateLimit) *RateLimiter {
+	limiter := &RateLimiter{
+		global: newTokenBucket(global),
+		groups: make(map[EndpointGroup]*tokenBucket, len(groups)),
+	}
+
+	for group, limit := range groups {
+		limiter.groups[group] = newTokenBucket(limit)
+	}
+
+	return limiter
+}
+
+// Limits the requests made by the client with the given rate limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.limiter = limiter
+	}
+}
+
+// endpointGroup returns the group of an endpoint path.
+func endpointGroup(path string) EndpointGroup {
+	switch {
+	case strings.HasPrefix(path, "/deploy"), strings.HasPrefix(path, "/status/"), strings.HasPrefix(path, "/stop/"):
+		return GroupDeployments
+	case strings.HasPrefix(path, "/session"):
+		return GroupSessions
+	case strings.HasPrefix(path, "/aom/"):
+		return GroupMatchmaker
+	case strings.HasPrefix(path, "/telemetry"):
+		return GroupTelemetry
+	}
+
+	return GroupOther
+}
+
+// wait blocks until a request on path is allowed, or until ctx is done.
+// The tokens of the global and group buckets are reserved together, so that none is lost when ctx ends.
+func (l *RateLimiter) wait(ctx context.Context, path string) error {
+	if l == nil {
+		return nil
+	}
+
+	buckets := []*tokenBucket{l.global, l.groups[endpointGroup(path)]}
+
+	var delay time.Duration
+
+	for _, bucket := range buckets {
+		delay = max(delay, bucket.reserve())
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Give back the reserved tokens
+		for _, bucket := range buckets {
+			bucket.release()
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// observe adapts the limits to the response received for a request on path.
+func (l *RateLimiter) observe(path string, res *resty.Response) {
+	if l == nil || res == nil || res.RawResponse == nil {
+		return
+	}
+
+	buckets := []*tokenBucket{l.global, l.groups[endpointGroup(path)]}
+
+	if res.StatusCode() == http.StatusTooManyRequests {
+		pause, _ := retryAfter(res.Header().Get("Retry-After"))
+
+		for _, bucket := range buckets {
+			bucket.throttle(pause)
+		}
+
+		return
+	}
+
+	if res.StatusCode() < 300 {
+		for _, bucket := range buckets {
+			bucket.recover()
+		}
+	}
+}
+
+type tokenBucket struct {
+	mu          sync.Mutex
+	limit       float64 // Configured rate
+	rate        float64 // Current rate, lowered after a 429
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// newTokenBucket returns nil for a zero limit, a nil bucket never blocks.
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		limit:  limit.Rate,
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// refill adds the tokens earned since the last call. Must be called with mu held.
+func (b *tokenBucket) refill(now time.Time) {
+	if now.Before(b.pausedUntil) {
+		b.last = now
+		return
+	}
+
+	from := b.last
+	if from.Before(b.pausedUntil) {
+		from = b.pausedUntil
+	}
+
+	b.tokens += now.Sub(from).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.last = now
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+	b.tokens--
+
+	var delay time.Duration
+
+	if now.Before(b.pausedUntil) {
+		delay = b.pausedUntil.Sub(now)
+	}
+
+	if b.tokens < 0 {
+		delay += time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+
+	return delay
+}
+
+// release gives back a token taken by reserve and not used.
+func (b *tokenBucket) release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+// throttle halves the current rate, down to a sixteenth of the configured one, and pauses the bucket for pause.
+func (b *tokenBucket) throttle(pause time.Duration) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+
+	b.rate /= 2
+	if floor := b.limit / 16; b.rate < floor {
+		b.rate = floor
+	}
+
+	if until := now.Add(pause); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// recover raises the current rate back toward the configured one by a tenth of it.
+func (b *tokenBucket) recover() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate >= b.limit {
+		return
+	}
+
+	b.refill(time.Now())
+
+	b.rate += b.limit / 10
+	if b.rate > b.limit {
+		b.rate = b.limit
+	}
+}
@@ -0,0 +1,104 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := edgegap.NewRateLimiter(edgegap.RateLimit{Rate: 20, Burst: 1}, nil)
+	client := newStubClient(t, publicIP, edgegap.WithRateLimiter(limiter))
+
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.IPGet(context.Background()); err != nil {
+			t.Fatalf("IPGet() error = %v", err)
+		}
+	}
+
+	// The first request uses the burst, the three following ones wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+		t.Errorf("4 requests took %s, want at least 150ms", elapsed)
+	}
+}
+
+func TestRateLimiterGroups(t *testing.T) {
+	limiter := edgegap.NewRateLimiter(edgegap.RateLimit{}, map[edgegap.EndpointGroup]edgegap.RateLimit{
+		edgegap.GroupDeployments: {Rate: 1, Burst: 1},
+	})
+	client := newStubClient(t, publicIP, edgegap.WithRateLimiter(limiter))
+
+	if _, err := client.DeploymentGetStatus(context.Background(), "9f511e17dfa4"); err != nil {
+		t.Fatalf("DeploymentGetStatus() error = %v", err)
+	}
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.IPGet(context.Background()); err != nil {
+			t.Fatalf("IPGet() error = %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("unlimited requests took %s, want no wait", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.DeploymentGetStatus(ctx, "9f511e17dfa4"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DeploymentGetStatus() error = %v, want the deadline to expire while waiting", err)
+	}
+}
+
+func TestRateLimiterKeepsGlobalTokenOnGroupWait(t *testing.T) {
+	limiter := edgegap.NewRateLimiter(edgegap.RateLimit{Rate: 1, Burst: 2}, map[edgegap.EndpointGroup]edgegap.RateLimit{
+		edgegap.GroupDeployments: {Rate: 1, Burst: 1},
+	})
+	client := newStubClient(t, publicIP, edgegap.WithRateLimiter(limiter))
+
+	if _, err := client.DeploymentGetStatus(context.Background(), "9f511e17dfa4"); err != nil {
+		t.Fatalf("DeploymentGetStatus() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.DeploymentGetStatus(ctx, "9f511e17dfa4"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DeploymentGetStatus() error = %v, want the deadline to expire while waiting", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The second global token was given back when the deployments group wait failed.
+	if _, err := client.IPGet(ctx); err != nil {
+		t.Errorf("IPGet() error = %v, want the global token left by the canceled request", err)
+	}
+}
+
+func TestRateLimiterBacksOffOn429(t *testing.T) {
+	limiter := edgegap.NewRateLimiter(edgegap.RateLimit{Rate: 1000, Burst: 1}, nil)
+	client := newStubClient(t,
+		failing(1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}}, publicIP),
+		edgegap.WithRateLimiter(limiter),
+	)
+
+	if _, err := client.IPGet(context.Background()); !edgegap.IsRateLimited(err) {
+		t.Fatalf("IPGet() error = %v, want rate limited", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.IPGet(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("IPGet() error = %v, want the limiter to pause for the Retry-After duration", err)
+	}
+}
@@ -0,0 +1,153 @@
+package edgegap
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy controls how failed requests are retried. The zero value disables retries.
+type RetryPolicy struct {
+	MaxAttempts        int           // Total number of attempts, including the first one. Values below 2 disable retries.
+	BaseDelay          time.Duration // Delay before the first retry, doubled on every following attempt
+	MaxDelay           time.Duration // Upper bound of a single delay, including the one asked by a Retry-After header
+	RetryOn            []int         // HTTP status codes worth a retry. Defaults to 429, 502, 503 and 504 when empty
+	RetryNonIdempotent bool          // If POST and PATCH calls (i.e. DeploymentCreate) can be retried too
+}
+
+// Attempt describes a single HTTP attempt made while serving a request.
+type Attempt struct {
+	Number     int           // 1 for the first attempt
+	StatusCode int           // HTTP status code, 0 when no response was received
+	Duration   time.Duration // Time spent on the attempt
+	Delay      time.Duration // Time waited before the next attempt, 0 for the last one
+	Error      error         // Error of the attempt, if any
+}
+
+type retryOptInKey struct{}
+
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// A sensible policy : 4 attempts with a jittered backoff starting at 500ms and capped at 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// Sets the retry policy used for every request made by the client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = policy
+	}
+}
+
+// Returns a context allowing the calls made with it to be retried even when they are not idempotent (i.e. DeploymentCreate).
+func WithRetryNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryOptInKey{}, true)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// next reports if the attempt should be retried and how long to wait before doing so.
+func (p RetryPolicy) next(ctx context.Context, method string, attempt int, res *resty.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if !isIdempotent(method) && !p.RetryNonIdempotent {
+		if optIn, _ := ctx.Value(retryOptInKey{}).(bool); !optIn {
+			return 0, false
+		}
+	}
+
+	if res == nil || res.RawResponse == nil {
+		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+
+		return p.backoff(attempt), true
+	}
+
+	statuses := p.RetryOn
+	if len(statuses) == 0 {
+		statuses = defaultRetryStatuses
+	}
+
+	if !slices.Contains(statuses, res.StatusCode()) {
+		return 0, false
+	}
+
+	if delay, ok := retryAfter(res.Header().Get("Retry-After")); ok {
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+
+		return delay, true
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns a jittered exponential delay, between half and the whole of BaseDelay * 2^(attempt-1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+	}
+
+	half := delay / 2
+
+	return half + rand.N(half+1)
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+
+		return delay, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,133 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// fastRetries retries quickly so the tests do not wait on the default backoff.
+var fastRetries = edgegap.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+// failing answers the first times requests with status and header, then hands them to next. A negative times fails every request.
+func failing(times int, status int, header http.Header, next http.HandlerFunc) http.HandlerFunc {
+	var count atomic.Int64
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if times >= 0 && count.Add(1) > int64(times) {
+			next(w, r)
+			return
+		}
+
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"message":%q}`, http.StatusText(status))
+	}
+}
+
+// deploymentCreated answers the requests of DeploymentCreate.
+func deploymentCreated(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, `{"request_id":"9f511e17dfa4"}`)
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	client := newStubClient(t, failing(2, http.StatusServiceUnavailable, nil, publicIP), edgegap.WithRetryPolicy(fastRetries))
+
+	res, err := client.IPGet(context.Background())
+	if err != nil {
+		t.Fatalf("IPGet() error = %v", err)
+	}
+
+	if len(res.Attempts) != 3 {
+		t.Fatalf("Attempts = %d, want 3", len(res.Attempts))
+	}
+
+	if res.Attempts[0].StatusCode != http.StatusServiceUnavailable || res.Attempts[2].StatusCode != http.StatusOK {
+		t.Errorf("Attempts = %+v, want two 503 then a 200", res.Attempts)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	client := newStubClient(t, failing(-1, http.StatusBadGateway, nil, publicIP), edgegap.WithRetryPolicy(fastRetries))
+
+	res, err := client.IPGet(context.Background())
+
+	if err == nil || len(res.Attempts) != fastRetries.MaxAttempts {
+		t.Fatalf("IPGet() = %d attempts, error %v, want %d failed attempts", len(res.Attempts), err, fastRetries.MaxAttempts)
+	}
+}
+
+func TestRetrySkipsNonIdempotentCalls(t *testing.T) {
+	payload := &edgegap.DeployementCreatePayload{AppName: "game", VersionName: "v1"}
+
+	client := newStubClient(t, failing(1, http.StatusServiceUnavailable, nil, deploymentCreated), edgegap.WithRetryPolicy(fastRetries))
+
+	res, err := client.DeploymentCreate(context.Background(), payload)
+	if err == nil || len(res.Attempts) != 1 {
+		t.Fatalf("DeploymentCreate() = %d attempts, error %v, want a single failed attempt", len(res.Attempts), err)
+	}
+
+	client = newStubClient(t, failing(1, http.StatusServiceUnavailable, nil, deploymentCreated), edgegap.WithRetryPolicy(fastRetries))
+
+	res, err = client.DeploymentCreate(edgegap.WithRetryNonIdempotent(context.Background()), payload)
+	if err != nil || len(res.Attempts) != 2 {
+		t.Fatalf("DeploymentCreate() opted in = %d attempts, error %v, want a retried success", len(res.Attempts), err)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	client := newStubClient(t,
+		failing(1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}}, publicIP),
+		edgegap.WithRetryPolicy(edgegap.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour, MaxDelay: time.Hour}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := client.IPGet(ctx)
+	if err != nil {
+		t.Fatalf("IPGet() error = %v", err)
+	}
+
+	if res.Attempts[0].Delay != 0 {
+		t.Errorf("Delay = %s, want the 0s asked by Retry-After", res.Attempts[0].Delay)
+	}
+}
+
+func TestRetryInterruptedByContext(t *testing.T) {
+	client := newStubClient(t,
+		failing(-1, http.StatusServiceUnavailable, nil, publicIP),
+		edgegap.WithRetryPolicy(edgegap.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.IPGet(ctx)
+
+	var apiErr *edgegap.APIError
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &apiErr) {
+		t.Errorf("IPGet() error = %v, want the deadline and the last APIError", err)
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	client := newStubClient(t, failing(1, http.StatusServiceUnavailable, nil, publicIP))
+
+	res, err := client.IPGet(context.Background())
+	if err == nil || len(res.Attempts) != 1 {
+		t.Fatalf("IPGet() = %d attempts, error %v, want a single failed attempt", len(res.Attempts), err)
+	}
+}
@@ -0,0 +1,196 @@
+// Package server is used by game servers running inside an Edgegap deployment.
+//
+// Edgegap injects ARBITRIUM_* environment variables in the container of every deployment. The package parses them
+// into an Environment, giving the request ID, public IP, FQDN, ports and location of the deployment, and uses the
+// injected URLs and tokens to get the context of the deployment and to stop it from inside the container.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// Environment variables injected by Edgegap in the container of a deployment.
+const (
+	EnvRequestID    = "ARBITRIUM_REQUEST_ID"          // Request ID of the deployment
+	EnvPublicIP     = "ARBITRIUM_PUBLIC_IP"           // Public IP of the deployment
+	EnvFQDN         = "ARBITRIUM_FQDN"                // FQDN to connect to the deployment
+	EnvHostID       = "ARBITRIUM_HOST_ID"             // ID of the host running the deployment
+	EnvTags         = "ARBITRIUM_DEPLOYMENT_TAGS"     // Tags of the deployment, separated by commas
+	EnvPortsMapping = "ARBITRIUM_PORTS_MAPPING"       // Ports of the deployment, as JSON
+	EnvLocation     = "ARBITRIUM_DEPLOYMENT_LOCATION" // Location of the deployment, as JSON
+	EnvDeleteURL    = "ARBITRIUM_DELETE_URL"          // URL to stop the deployment from inside its container
+	EnvDeleteToken  = "ARBITRIUM_DELETE_TOKEN"        // Token to stop the deployment from inside its container
+	EnvContextURL   = "ARBITRIUM_CONTEXT_URL"         // URL to get the context of the deployment from inside its container
+	EnvContextToken = "ARBITRIUM_CONTEXT_TOKEN"       // Token to get the context of the deployment from inside its container
+)
+
+// ErrNotInDeployment is returned when the environment has no ARBITRIUM_REQUEST_ID, i.e. outside of a deployment.
+var ErrNotInDeployment = errors.New("server: not running inside an Edgegap deployment")
+
+// LookupFunc returns the value of an environment variable and if it is set, like os.LookupEnv.
+type LookupFunc func(key string) (string, bool)
+
+// Location is the location of a deployment, as injected in ARBITRIUM_DEPLOYMENT_LOCATION.
+type Location struct {
+	edgegap.Location
+
+	City                   string `json:"city"`                    // City Name
+	Country                string `json:"country"`                 // Country name
+	Continent              string `json:"continent"`               // Continent Name
+	AdministrativeDivision string `json:"administrative_division"` // Administrative Division
+	Timezone               string `json:"timezone"`                // Timezone name
+}
+
+// Environment is what Edgegap injects in the container of a deployment.
+type Environment struct {
+	RequestID    string                         // The Unique ID of the Deployment's request
+	PublicIP     string                         // The public IP
+	FQDN         string                         // The FQDN that allow to connect to the Deployment
+	HostID       string                         // The ID of the host running the Deployment
+	Tags         []string                       // List of tags associated with the deployment
+	Ports        map[string]edgegap.PortDetails // Ports of the Deployment, by name
+	Location     Location                       // Location of the Deployment
+	DeleteURL    string                         // URL to stop the Deployment, see Stop
+	DeleteToken  string                         // Token to stop the Deployment, see Stop
+	ContextURL   string                         // URL to get the context of the Deployment, see Context
+	ContextToken string                         // Token to get the context of the Deployment, see Context
+}
+
+// Parses the environment of the current process.
+func FromEnv() (*Environment, error) {
+	return Parse(os.LookupEnv)
+}
+
+// Parses an environment given as a map, i.e. a fake environment in tests.
+func FromMap(env map[string]string) (*Environment, error) {
+	return Parse(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	})
+}
+
+// Parses the environment read with lookup. It returns ErrNotInDeployment when ARBITRIUM_REQUEST_ID is not set.
+func Parse(lookup LookupFunc) (*Environment, error) {
+	get := func(key string) string {
+		value, _ := lookup(key)
+		return strings.TrimSpace(value)
+	}
+
+	env := &Environment{
+		RequestID:    get(EnvRequestID),
+		PublicIP:     get(EnvPublicIP),
+		FQDN:         get(EnvFQDN),
+		HostID:       get(EnvHostID),
+		Ports:        map[string]edgegap.PortDetails{},
+		DeleteURL:    get(EnvDeleteURL),
+		DeleteToken:  get(EnvDeleteToken),
+		ContextURL:   get(EnvContextURL),
+		ContextToken: get(EnvContextToken),
+	}
+
+	if env.RequestID == "" {
+		return nil, ErrNotInDeployment
+	}
+
+	for _, tag := range strings.Split(get(EnvTags), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			env.Tags = append(env.Tags, tag)
+		}
+	}
+
+	if raw := get(EnvPortsMapping); raw != "" {
+		var mapping struct {
+			Ports map[string]edgegap.PortDetails `json:"ports"`
+		}
+
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return nil, fmt.Errorf("server: parsing %s : %w", EnvPortsMapping, err)
+		}
+
+		for name, port := range mapping.Ports {
+			if port.Name == "" {
+				port.Name = name
+			}
+
+			env.Ports[name] = port
+		}
+	}
+
+	if raw := get(EnvLocation); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &env.Location); err != nil {
+			return nil, fmt.Errorf("server: parsing %s : %w", EnvLocation, err)
+		}
+	}
+
+	return env, nil
+}
+
+// Returns the port with the given name, as set on the application version.
+func (e *Environment) Port(name string) (edgegap.PortDetails, bool) {
+	port, ok := e.Ports[name]
+
+	return port, ok
+}
+
+// Returns the external port players connect to for the port with the given name.
+func (e *Environment) ExternalPort(name string) (int, bool) {
+	port, ok := e.Ports[name]
+
+	return port.External, ok
+}
+
+// Returns the external port mapped to an internal port, the one the game server listens on.
+func (e *Environment) ExternalPortFor(internal int) (int, bool) {
+	for _, port := range e.Ports {
+		if port.Internal == internal {
+			return port.External, true
+		}
+	}
+
+	return 0, false
+}
+
+// Get the current state of the deployment, with the URL and token of its context.
+// A nil client uses a client with no token, the context token being the only one needed.
+func (e *Environment) Context(ctx context.Context, client *edgegap.EdgegapClient) (*edgegap.DeploymentInfo, error) {
+	if e.ContextURL == "" || e.ContextToken == "" {
+		return nil, fmt.Errorf("server: %s and %s must be set to get the context", EnvContextURL, EnvContextToken)
+	}
+
+	res, err := clientOrDefault(client).Deployments.SelfContext(ctx, e.ContextURL, e.ContextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// Stop the deployment, with its delete URL and token. Stopping is asynchronous : the container receives a SIGTERM
+// once the deployment is being terminated. A nil client uses a client with no token, the delete token being the only one needed.
+func (e *Environment) Stop(ctx context.Context, client *edgegap.EdgegapClient) (*edgegap.DeploymentStopResponse, error) {
+	if e.DeleteURL == "" || e.DeleteToken == "" {
+		return nil, fmt.Errorf("server: %s and %s must be set to stop the deployment", EnvDeleteURL, EnvDeleteToken)
+	}
+
+	res, err := clientOrDefault(client).Deployments.SelfStop(ctx, e.DeleteURL, e.DeleteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+func clientOrDefault(client *edgegap.EdgegapClient) *edgegap.EdgegapClient {
+	if client != nil {
+		return client
+	}
+
+	return edgegap.NewEdgegapClient("")
+}
@@ -0,0 +1,115 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+	"github.com/kisshan13/go-edgegap/server"
+)
+
+func TestParse(t *testing.T) {
+	env, err := server.FromMap(map[string]string{
+		"ARBITRIUM_REQUEST_ID":          "9f511e17dfa4",
+		"ARBITRIUM_PUBLIC_IP":           "203.0.113.7",
+		"ARBITRIUM_FQDN":                "9f511e17dfa4.pr.edgegap.net",
+		"ARBITRIUM_DEPLOYMENT_TAGS":     "ranked, eu,",
+		"ARBITRIUM_PORTS_MAPPING":       `{"ports":{"gameport":{"internal":7777,"external":31504,"protocol":"UDP"},"web":{"name":"web","internal":8080,"external":31505,"protocol":"HTTPS","tls_upgrade":true}}}`,
+		"ARBITRIUM_DEPLOYMENT_LOCATION": `{"city":"Montreal","country":"Canada","continent":"North America","administrative_division":"Quebec","timezone":"America/Toronto","latitude":45.5,"longitude":-73.56}`,
+	})
+	if err != nil {
+		t.Fatalf("FromMap() error = %v", err)
+	}
+
+	if env.RequestID != "9f511e17dfa4" || env.PublicIP != "203.0.113.7" || env.FQDN != "9f511e17dfa4.pr.edgegap.net" {
+		t.Errorf("FromMap() = %+v", env)
+	}
+
+	if len(env.Tags) != 2 || env.Tags[0] != "ranked" || env.Tags[1] != "eu" {
+		t.Errorf("Tags = %v, want [ranked eu]", env.Tags)
+	}
+
+	if port, ok := env.Port("gameport"); !ok || port.Name != "gameport" || port.Protocol != "UDP" {
+		t.Errorf("Port(gameport) = %+v, %v", port, ok)
+	}
+
+	if external, ok := env.ExternalPort("web"); !ok || external != 31505 {
+		t.Errorf("ExternalPort(web) = %d, %v, want 31505", external, ok)
+	}
+
+	if external, ok := env.ExternalPortFor(7777); !ok || external != 31504 {
+		t.Errorf("ExternalPortFor(7777) = %d, %v, want 31504", external, ok)
+	}
+
+	if _, ok := env.ExternalPort("missing"); ok {
+		t.Error("ExternalPort(missing) found a port")
+	}
+
+	if env.Location.City != "Montreal" || env.Location.Latitude != 45.5 {
+		t.Errorf("Location = %+v", env.Location)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := server.FromMap(map[string]string{}); !errors.Is(err, server.ErrNotInDeployment) {
+		t.Errorf("FromMap() with no request ID error = %v, want ErrNotInDeployment", err)
+	}
+
+	_, err := server.FromMap(map[string]string{
+		"ARBITRIUM_REQUEST_ID":    "9f511e17dfa4",
+		"ARBITRIUM_PORTS_MAPPING": "{",
+	})
+	if err == nil {
+		t.Error("FromMap() with an invalid ports mapping succeeded")
+	}
+}
+
+func TestContextAndStop(t *testing.T) {
+	ctx := context.Background()
+
+	fake := edgegaptest.NewServer()
+	t.Cleanup(fake.Close)
+
+	fake.AddApplication(
+		edgegap.Application{Name: "game", IsActive: true},
+		edgegap.ApplicationVersion{
+			Name:  "v1",
+			Ports: []edgegap.ApplicationPort{{Port: 7777, Protocol: edgegap.ProtocolUDP, Name: "gameport"}},
+		},
+	)
+
+	res, err := fake.Client().Deployments.Create(ctx, &edgegap.DeployementCreatePayload{AppName: "game", IpList: []string{"1.2.3.4"}})
+	if err != nil {
+		t.Fatalf("Deployments.Create() error = %v", err)
+	}
+
+	vars, _ := fake.Env(res.Data.RequestID)
+
+	env, err := server.FromMap(vars)
+	if err != nil {
+		t.Fatalf("FromMap() error = %v", err)
+	}
+
+	if _, ok := env.ExternalPortFor(7777); !ok {
+		t.Errorf("ExternalPortFor(7777) found no port in %v", env.Ports)
+	}
+
+	info, err := env.Context(ctx, nil)
+	if err != nil {
+		t.Fatalf("Context() error = %v", err)
+	}
+
+	if info.RequestID != env.RequestID || info.Ports["gameport"] != env.Ports["gameport"] {
+		t.Errorf("Context() = %+v, want the deployment of %+v", info, env)
+	}
+
+	if _, err := env.Stop(ctx, nil); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if _, err := fake.Client().WaitForStopped(ctx, env.RequestID, edgegap.WaitOptions{Interval: 1}); err != nil {
+		t.Errorf("WaitForStopped() error = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestServices(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	if _, err := client.Applications.Versions.ACL.Create(ctx, "game", "v1", edgegap.ApplicationACL{CIDR: "198.51.100.0/24"}); err != nil {
+		t.Fatalf("Applications.Versions.ACL.Create() error = %v", err)
+	}
+
+	entries, err := client.Applications.Versions.ACL.List(ctx, "game", "v1")
+	if err != nil {
+		t.Fatalf("Applications.Versions.ACL.List() error = %v", err)
+	}
+
+	if len(entries.Data.WhitelistEntries) != 1 {
+		t.Errorf("Applications.Versions.ACL.List() = %d entries, want 1", len(entries.Data.WhitelistEntries))
+	}
+
+	if _, err := client.Matchmaker.Components.Create(ctx, edgegap.MatchmakerComponentCreate{Name: "frontend", Image: "studio/frontend"}); err != nil {
+		t.Fatalf("Matchmaker.Components.Create() error = %v", err)
+	}
+
+	if _, err := client.Matchmaker.Components.Envs.Create(ctx, "frontend", edgegap.MatchmakerEnv{Key: "MODE", Value: "ranked"}); err != nil {
+		t.Fatalf("Matchmaker.Components.Envs.Create() error = %v", err)
+	}
+
+	env, err := client.Matchmaker.Components.Envs.Get(ctx, "frontend", "MODE")
+	if err != nil {
+		t.Fatalf("Matchmaker.Components.Envs.Get() error = %v", err)
+	}
+
+	if env.Data.Value != "ranked" {
+		t.Errorf("Matchmaker.Components.Envs.Get() = %q, want %q", env.Data.Value, "ranked")
+	}
+
+	created, err := client.Deployments.Create(ctx, &edgegap.DeployementCreatePayload{AppName: "game", VersionName: "v1"})
+	if err != nil {
+		t.Fatalf("Deployments.Create() error = %v", err)
+	}
+
+	session, err := client.Sessions.Create(ctx, &edgegap.SessionCreate{App: "game", DeploymentRequestID: created.Data.RequestID})
+	if err != nil {
+		t.Fatalf("Sessions.Create() error = %v", err)
+	}
+
+	users, err := client.Sessions.Users.Add(ctx, session.Data.SessionID, []string{"198.51.100.1"})
+	if err != nil {
+		t.Fatalf("Sessions.Users.Add() error = %v", err)
+	}
+
+	if len(users.Data.Users) != 1 {
+		t.Errorf("Sessions.Users.Add() = %d users, want 1", len(users.Data.Users))
+	}
+}
+
+func TestDeprecatedMethodsDelegate(t *testing.T) {
+	var operations []string
+
+	server, client := newTestClient(t, edgegap.WithMiddleware(func(next edgegap.RoundTrip) edgegap.RoundTrip {
+		return func(ctx context.Context, call *edgegap.Call) (*edgegap.Result, error) {
+			operations = append(operations, call.Operation)
+			return next(ctx, call)
+		}
+	}))
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	if _, err := client.DeploymentGetStatus(context.Background(), id); err != nil {
+		t.Fatalf("DeploymentGetStatus() error = %v", err)
+	}
+
+	if _, err := client.Deployments.Get(context.Background(), id); err != nil {
+		t.Fatalf("Deployments.Get() error = %v", err)
+	}
+
+	if len(operations) != 2 || operations[0] != operations[1] {
+		t.Errorf("operations = %v, want the same operation for both calls", operations)
+	}
+}
@@ -5,9 +5,19 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"net/http"
+)
 
-	"github.com/go-resty/resty/v2"
+// Statuses reported by Session.Status.
+const (
+	SessionStatusSeeking       = "Status.SEEKING"
+	SessionStatusLinked        = "Status.LINKED"
+	SessionStatusReady         = "Status.READY"
+	SessionStatusUnprocessable = "Status.UNPROCESSABLE"
+	SessionStatusError         = "Status.ERROR"
 )
 
 type SessionCreate struct {
@@ -76,80 +86,140 @@ type SessionUser struct {
 	Longitude int    `json:"longitude"`
 }
 
+// SessionsService manages the sessions, see EdgegapClient.Sessions.
+type SessionsService struct {
+	client *EdgegapClient
+
+	Users *SessionUsersService // Users of the sessions
+}
+
+// SessionUsersService manages the users of the sessions, see SessionsService.Users.
+type SessionUsersService struct {
+	client *EdgegapClient
+}
+
 // Create a session with users. Sessions are linked to a deployment.
-func (e *EdgegapClient) SessionCreate(session *SessionCreate) (*Response[SessionCreateRes], error) {
+func (s *SessionsService) Create(ctx context.Context, session *SessionCreate) (*Response[SessionCreateRes], error) {
 	var response SessionCreateRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(session).Post("/session")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionCreate",
+		method:    http.MethodPost,
+		path:      "/session",
+		body:      session,
 	}, &response)
 }
 
 // Delete a session. Once deleted, a session is no more accessible and does not have a history. The deployment associated will not be deleted.
-func (e *EdgegapClient) SessionDelete(id string) (*Response[SessionDeleteRes], error) {
+func (s *SessionsService) Delete(ctx context.Context, id string) (*Response[SessionDeleteRes], error) {
 	var response SessionDeleteRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Delete(fmt.Sprintf("/session/%s", id))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionDelete",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/session/%s", id),
+		params:    map[string]string{paramSessionID: id},
 	}, &response)
 }
 
 // Retrieve the information for a session.
-func (e *EdgegapClient) SessionGet(id string) (*Response[Session], error) {
+func (s *SessionsService) Get(ctx context.Context, id string) (*Response[Session], error) {
 	var response Session
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/session/%s", id))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionGet",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/session/%s", id),
+		params:    map[string]string{paramSessionID: id},
 	}, &response)
 }
 
 // Add specified users to a session.
-func (e *EdgegapClient) SessionPutUsers(id string, ips []string) (*Response[SessionUserRes], error) {
+func (s *SessionUsersService) Add(ctx context.Context, id string, ips []string) (*Response[SessionUserRes], error) {
 	var response SessionUserRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string][]string{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionPutUsers",
+		method:    http.MethodPut,
+		path:      fmt.Sprintf("/session/%s/users", id),
+		params:    map[string]string{paramSessionID: id},
+		body: map[string][]string{
 			"ip_list": ips,
-		}).Put(fmt.Sprintf("/session/%s/users", id))
+		},
 	}, &response)
 }
 
 // Remove specified users from a session.
-func (e *EdgegapClient) SessionDeleteUsers(id string, ips []string) (*Response[SessionUserRes], error) {
+func (s *SessionUsersService) Remove(ctx context.Context, id string, ips []string) (*Response[SessionUserRes], error) {
 	var response SessionUserRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string][]string{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionDeleteUsers",
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/session/%s/users", id),
+		params:    map[string]string{paramSessionID: id},
+		body: map[string][]string{
 			"ip_list": ips,
-		}).Delete(fmt.Sprintf("/session/%s/users", id))
+		},
 	}, &response)
 }
 
 // List all users of session.
-func (e *EdgegapClient) SessionGetUsers(id string) (*Response[SessionUserRes], error) {
+func (s *SessionUsersService) List(ctx context.Context, id string) (*Response[SessionUserRes], error) {
 	var response SessionUserRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/session/%s/users", id))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionGetUsers",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/session/%s/users", id),
+		params:    map[string]string{paramSessionID: id},
+	}, &response)
+}
+
+// List all the active sessions. Only the first page is returned, use ListPage or Iterate to get the others.
+func (s *SessionsService) List(ctx context.Context) (*Response[ResponseBody[Session]], error) {
+	var response ResponseBody[Session]
+
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionListAll",
+		method:    http.MethodGet,
+		path:      "/session",
 	}, &response)
 }
 
-// List all the active sessions.
-func (e *EdgegapClient) SessionListAll() (*Response[ResponseBody[Session]], error) {
+// List a single page of the active sessions.
+func (s *SessionsService) ListPage(ctx context.Context, params PaginationParams) (*Response[ResponseBody[Session]], error) {
 	var response ResponseBody[Session]
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get("/session")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionListPage",
+		method:    http.MethodGet,
+		path:      "/session" + params.GetParams(),
 	}, &response)
 }
 
+// Iterate over every active session, fetching the pages as they are needed.
+func (s *SessionsService) Iterate(ctx context.Context) iter.Seq2[Session, error] {
+	return paginate(ctx, func(ctx context.Context, params PaginationParams) ([]Session, Pagination, error) {
+		res, err := s.ListPage(ctx, params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		return res.Data.Data, res.Data.Pagination, nil
+	})
+}
+
 // Make a bulk delete of sessions using filters. All the sessions matching the given filters will be permanently deleted.
-func (e *EdgegapClient) SessionBulkDelete(filters []Filter) (*Response[SessionBulkDeleteRes], error) {
+func (s *SessionsService) BulkDelete(ctx context.Context, filters []Filter) (*Response[SessionBulkDeleteRes], error) {
 	var response SessionBulkDeleteRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(map[string]interface{}{
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "SessionBulkDelete",
+		method:    http.MethodPost,
+		path:      "/sessions/bulk-stop",
+		body: map[string]interface{}{
 			"filters": filters,
-		}).Post("/sessions/bulk-stop")
+		},
 	}, &response)
 }
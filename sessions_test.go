@@ -0,0 +1,97 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+func TestSessionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	deployment := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v1", Sockets: 10})
+
+	created, err := client.SessionCreate(ctx, &edgegap.SessionCreate{
+		App:                 "game",
+		Version:             "v1",
+		IPList:              []string{"198.51.100.1"},
+		DeploymentRequestID: deployment,
+	})
+	if err != nil {
+		t.Fatalf("SessionCreate() error = %v", err)
+	}
+
+	id := created.Data.SessionID
+
+	if _, err := client.SessionPutUsers(ctx, id, []string{"198.51.100.2", "198.51.100.3"}); err != nil {
+		t.Fatalf("SessionPutUsers() error = %v", err)
+	}
+
+	if _, err := client.SessionDeleteUsers(ctx, id, []string{"198.51.100.1"}); err != nil {
+		t.Fatalf("SessionDeleteUsers() error = %v", err)
+	}
+
+	session, err := client.SessionGet(ctx, id)
+	if err != nil {
+		t.Fatalf("SessionGet() error = %v", err)
+	}
+
+	if !session.Data.Ready || session.Data.Status != edgegaptest.SessionStatusReady {
+		t.Errorf("session = %+v, want a ready session", session.Data)
+	}
+
+	if session.Data.UserCount != 2 || session.Data.Deployment.SocketsUsage != 2 {
+		t.Errorf("UserCount = %d, SocketsUsage = %d, want 2 and 2", session.Data.UserCount, session.Data.Deployment.SocketsUsage)
+	}
+
+	if _, err := client.SessionDelete(ctx, id); err != nil {
+		t.Fatalf("SessionDelete() error = %v", err)
+	}
+
+	if _, err := client.SessionGetUsers(ctx, id); !edgegap.IsNotFound(err) {
+		t.Errorf("SessionGetUsers() after delete error = %v, want not found", err)
+	}
+}
+
+func TestSessionIterateAndBulkDelete(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	const count = edgegaptest.DefaultPageSize + 3
+
+	for i := 0; i < count; i++ {
+		if _, err := client.SessionCreate(ctx, &edgegap.SessionCreate{App: "game"}); err != nil {
+			t.Fatalf("SessionCreate() error = %v", err)
+		}
+	}
+
+	var ids []string
+
+	for session, err := range client.SessionIterate(ctx) {
+		if err != nil {
+			t.Fatalf("SessionIterate() error = %v", err)
+		}
+
+		ids = append(ids, session.ID)
+	}
+
+	if len(ids) != count {
+		t.Fatalf("SessionIterate() yielded %d sessions, want %d", len(ids), count)
+	}
+
+	res, err := client.SessionBulkDelete(ctx, []edgegap.Filter{
+		{Field: edgegap.ESessionID, Values: ids[:2], FilterType: edgegap.EAny},
+	})
+	if err != nil {
+		t.Fatalf("SessionBulkDelete() error = %v", err)
+	}
+
+	if len(res.Data.Processable) != 2 {
+		t.Errorf("Processable = %v, want 2 sessions", res.Data.Processable)
+	}
+}
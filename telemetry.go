@@ -5,9 +5,9 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"net/http"
 )
 
 type TelemetryCreate struct {
@@ -27,20 +27,30 @@ type Telemetry struct {
 	PartialResult bool     `json:"partial_result,omitempty"` // If the score list is incomplete and missing request IDs. Can occur if you request the results before we receive telemetry from every deployment.
 }
 
+// TelemetryService reads the telemetry of the deployments, see EdgegapClient.Telemetry.
+type TelemetryService struct {
+	client *EdgegapClient
+}
+
 // Create a telemetry request to get the best deployment(s) for given IP(s). You can use this to add players on a running deployment. If you set a webhook URL, the result will be sent to it.
-func (e *EdgegapClient) TelemetryCreate(payload TelemetryCreate) (*Response[TelemetryCreateRes], error) {
+func (s *TelemetryService) Create(ctx context.Context, payload TelemetryCreate) (*Response[TelemetryCreateRes], error) {
 	var response TelemetryCreateRes
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.SetBody(payload).Post("/telemetry/active-deployments")
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "TelemetryCreate",
+		method:    http.MethodPost,
+		path:      "/telemetry/active-deployments",
+		body:      payload,
 	}, &response)
 }
 
 // Retrieve the results of a telemetry request on active deployment(s) for given IP(s). The score array is sorted from the best to the worse deployment. You can use this to add players on a running deployment.
-func (e *EdgegapClient) TelemetryList(id string) (*Response[Telemetry], error) {
+func (s *TelemetryService) Get(ctx context.Context, id string) (*Response[Telemetry], error) {
 	var response Telemetry
 
-	return makeRequest(e, func(c *resty.Request) (*resty.Response, error) {
-		return c.Get(fmt.Sprintf("/telemetry/active-deployments/%s", id))
+	return makeRequest(ctx, s.client, &apiRequest{
+		operation: "TelemetryList",
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/telemetry/active-deployments/%s", id),
 	}, &response)
 }
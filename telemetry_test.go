@@ -0,0 +1,32 @@
+package edgegap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+func TestTelemetry(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	created, err := client.TelemetryCreate(ctx, edgegap.TelemetryCreate{Deployments: []string{id, "unknown"}})
+	if err != nil {
+		t.Fatalf("TelemetryCreate() error = %v", err)
+	}
+
+	res, err := client.TelemetryList(ctx, created.Data.RetrievalKey)
+	if err != nil {
+		t.Fatalf("TelemetryList() error = %v", err)
+	}
+
+	if len(res.Data.Scores) != 1 {
+		t.Errorf("Scores = %v, want a single score", res.Data.Scores)
+	}
+
+	if _, err := client.MetricsByDeploymentID(ctx, id, edgegap.MetricsFilter{}); err != nil {
+		t.Errorf("MetricsByDeploymentID() error = %v", err)
+	}
+}
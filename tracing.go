@@ -0,0 +1,152 @@
+package edgegap
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/kisshan13/go-edgegap"
+
+// Attributes set on the spans created by TracingMiddleware.
+const (
+	AttrOperation  = attribute.Key("edgegap.operation")
+	AttrAppName    = attribute.Key("edgegap.app_name")
+	AttrAppVersion = attribute.Key("edgegap.app_version")
+	AttrRequestID  = attribute.Key("edgegap.request_id")
+	AttrSessionID  = attribute.Key("edgegap.session_id")
+	AttrRetryCount = attribute.Key("edgegap.retry_count")
+	AttrHTTPMethod = attribute.Key("http.request.method")
+	AttrHTTPStatus = attribute.Key("http.response.status_code")
+	AttrURLPath    = attribute.Key("url.path")
+)
+
+// Traces every call of the client with OpenTelemetry, see TracingMiddleware.
+// The tracing middleware is added after the middlewares registered by the previous options.
+func WithTracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) Option {
+	return WithMiddleware(TracingMiddleware(provider, propagator))
+}
+
+// TracingMiddleware creates one client span per call, named after the operation (i.e. "edgegap.DeploymentCreate"),
+// and injects its context in the request headers with propagator.
+// A nil provider or propagator falls back to the global ones registered in the otel package.
+func TracingMiddleware(provider trace.TracerProvider, propagator propagation.TextMapPropagator) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) (*Result, error) {
+			tp := provider
+			if tp == nil {
+				tp = otel.GetTracerProvider()
+			}
+
+			prop := propagator
+			if prop == nil {
+				prop = otel.GetTextMapPropagator()
+			}
+
+			ctx, span := tp.Tracer(tracerName).Start(ctx, "edgegap."+call.Operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					AttrOperation.String(call.Operation),
+					AttrHTTPMethod.String(call.Method),
+					AttrURLPath.String(call.Path),
+				),
+				trace.WithAttributes(callAttributes(call)...),
+			)
+			defer span.End()
+
+			if call.Header == nil {
+				call.Header = http.Header{}
+			}
+
+			prop.Inject(ctx, propagation.HeaderCarrier(call.Header))
+
+			result, err := next(ctx, call)
+
+			if result != nil {
+				if len(result.Attempts) > 0 {
+					span.SetAttributes(AttrRetryCount.Int(len(result.Attempts) - 1))
+
+					if status := result.Attempts[len(result.Attempts)-1].StatusCode; status != 0 {
+						span.SetAttributes(AttrHTTPStatus.Int(status))
+					}
+				}
+
+				span.SetAttributes(resultAttributes(result)...)
+			}
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return result, err
+		}
+	}
+}
+
+// callAttributes returns the attributes describing the resources targeted by call.
+func callAttributes(call *Call) []attribute.KeyValue {
+	params := map[string]string{}
+
+	switch body := call.Body.(type) {
+	case *DeployementCreatePayload:
+		if body != nil {
+			params[paramAppName] = body.AppName
+			params[paramAppVersion] = body.VersionName
+		}
+	case DeploymentAvailableSocketPayload:
+		params[paramAppName] = body.AppName
+		params[paramAppVersion] = body.AppVersion
+	case *SessionCreate:
+		if body != nil {
+			params[paramAppName] = body.App
+			params[paramAppVersion] = body.Version
+			params[paramRequestID] = body.DeploymentRequestID
+		}
+	}
+
+	for key, value := range call.Params {
+		params[key] = value
+	}
+
+	return paramAttributes(params)
+}
+
+// resultAttributes returns the attributes of the resources created by a call, such as the request ID of a new deployment.
+func resultAttributes(result *Result) []attribute.KeyValue {
+	params := map[string]string{}
+
+	switch data := result.Data.(type) {
+	case *DeploymentCreateResponse:
+		params[paramRequestID] = data.RequestID
+	case *SessionCreateRes:
+		params[paramSessionID] = data.SessionID
+		params[paramRequestID] = data.DeploymentRequestId
+	}
+
+	return paramAttributes(params)
+}
+
+func paramAttributes(params map[string]string) []attribute.KeyValue {
+	keys := map[string]attribute.Key{
+		paramAppName:    AttrAppName,
+		paramAppVersion: AttrAppVersion,
+		paramRequestID:  AttrRequestID,
+		paramSessionID:  AttrSessionID,
+	}
+
+	var attributes []attribute.KeyValue
+
+	for param, key := range keys {
+		if value := params[param]; value != "" {
+			attributes = append(attributes, key.String(value))
+		}
+	}
+
+	return attributes
+}
@@ -0,0 +1,127 @@
+package edgegap_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var traceparent string
+
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		deploymentCreated(w, r)
+	}, edgegap.WithTracing(provider, propagation.TraceContext{}))
+
+	created, err := client.DeploymentCreate(context.Background(), &edgegap.DeployementCreatePayload{AppName: "game", VersionName: "v1"})
+	if err != nil {
+		t.Fatalf("DeploymentCreate() error = %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans recorded, want 1", len(spans))
+	}
+
+	span := spans[0]
+
+	if span.Name() != "edgegap.DeploymentCreate" {
+		t.Errorf("span name = %q, want %q", span.Name(), "edgegap.DeploymentCreate")
+	}
+
+	if traceparent == "" || span.SpanContext().TraceID().String() != traceparent[3:35] {
+		t.Errorf("traceparent = %q, want the context of span %s", traceparent, span.SpanContext().TraceID())
+	}
+
+	want := map[attribute.Key]string{
+		edgegap.AttrOperation:  "DeploymentCreate",
+		edgegap.AttrAppName:    "game",
+		edgegap.AttrAppVersion: "v1",
+		edgegap.AttrRequestID:  created.Data.RequestID,
+		edgegap.AttrHTTPMethod: http.MethodPost,
+	}
+
+	for key, value := range want {
+		if got, _ := spanAttribute(span, key); got.AsString() != value {
+			t.Errorf("attribute %s = %q, want %q", key, got.AsString(), value)
+		}
+	}
+
+	if got, _ := spanAttribute(span, edgegap.AttrHTTPStatus); got.AsInt64() != http.StatusOK {
+		t.Errorf("attribute %s = %d, want 200", edgegap.AttrHTTPStatus, got.AsInt64())
+	}
+}
+
+func TestTracingRecordsErrors(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := newStubClient(t, failing(-1, http.StatusInternalServerError, nil, publicIP), edgegap.WithTracing(provider, nil))
+
+	if _, err := client.SessionGet(context.Background(), "abc-S"); err == nil {
+		t.Fatal("SessionGet() error = nil, want an error")
+	}
+
+	span := recorder.Ended()[0]
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want an error", span.Status())
+	}
+
+	if got, _ := spanAttribute(span, edgegap.AttrSessionID); got.AsString() != "abc-S" {
+		t.Errorf("attribute %s = %q, want %q", edgegap.AttrSessionID, got.AsString(), "abc-S")
+	}
+
+	if got, _ := spanAttribute(span, edgegap.AttrHTTPStatus); got.AsInt64() != http.StatusInternalServerError {
+		t.Errorf("attribute %s = %d, want 500", edgegap.AttrHTTPStatus, got.AsInt64())
+	}
+}
+
+func TestTracingRetryCount(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client := newStubClient(t,
+		failing(2, http.StatusServiceUnavailable, nil, publicIP),
+		edgegap.WithTracing(provider, nil),
+		edgegap.WithRetryPolicy(fastRetries),
+	)
+
+	if _, err := client.IPGet(context.Background()); err != nil {
+		t.Fatalf("IPGet() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans exported, want 1", len(spans))
+	}
+
+	for _, kv := range spans[0].Attributes {
+		if kv.Key == edgegap.AttrRetryCount && kv.Value.AsInt64() == 2 {
+			return
+		}
+	}
+
+	t.Errorf("attributes = %v, want %s = 2", spans[0].Attributes, edgegap.AttrRetryCount)
+}
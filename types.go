@@ -15,13 +15,16 @@ const (
 )
 
 const (
-	ProtocolTCP       = Protocol("TCP")
-	ProtocolUDP       = Protocol("UDP")
+	ProtocolTCP    = Protocol("TCP")
+	ProtocolUDP    = Protocol("UDP")
+	ProtocolTCPUDP = Protocol("TCP/UDP")
+	ProtocolHTTP   = Protocol("HTTP")
+	ProtocolHTTPS  = Protocol("HTTPS")
+	ProtocolWS     = Protocol("WS")
+	ProtocolWSS    = Protocol("WSS")
+
+	// Deprecated: misspelled, the API does not know it. Use ProtocolTCPUDP instead.
 	ProtocolTCPAndUDP = Protocol("TPC/UDP")
-	ProtocolHTTP      = Protocol("HTTP")
-	ProtocolHTTPS     = Protocol("HTTPS")
-	ProtocolWS        = Protocol("WS")
-	ProtocolWSS       = Protocol("WSS")
 )
 
 const (
@@ -55,6 +58,7 @@ type Response[T any] struct {
 	Response *resty.Response // Raw response for the request
 	Data     *T              // Data from the server on request complete
 	Error    error           // error
+	Attempts []Attempt       // Every HTTP attempt made to serve the request, retries included
 }
 
 type Pagination struct {
@@ -1,9 +1,10 @@
 package edgegap
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-resty/resty/v2"
+	"net/http"
+	"time"
 )
 
 // Utility function to get query paramters for pagination parameters.
@@ -11,35 +12,142 @@ func (pp *PaginationParams) GetParams() string {
 	return fmt.Sprintf("?page=%d&limit=%d", pp.Page, pp.Size)
 }
 
-func makeRequest[T any](e *EdgegapClient, request func(c *resty.Request) (*resty.Response, error), response *T) (*Response[T], error) {
-	var errorResponse ErrorResponse
+// apiRequest describes a single call to the API, relative to the client's base URL.
+type apiRequest struct {
+	operation string
+	method    string
+	path      string
+	params    map[string]string
+	body      interface{}
+	header    http.Header // Headers overriding the client ones, i.e. another Authorization
+}
 
-	res, err := request(e.client.R().SetError(&errorResponse).SetResult(response))
+// Keys of the identifiers a call can target, see Call.Params.
+const (
+	paramAppName    = "app_name"
+	paramAppVersion = "app_version"
+	paramRequestID  = "request_id"
+	paramSessionID  = "session_id"
+)
 
-	if err != nil {
-		return &Response[T]{
-			Success:  false,
-			Data:     nil,
-			Response: res,
-			Error:    err,
-		}, err
+// makeRequest runs request through the client's middlewares and decodes the result into response.
+func makeRequest[T any](ctx context.Context, e *EdgegapClient, request *apiRequest, response *T) (*Response[T], error) {
+	call := &Call{
+		Operation: request.operation,
+		Method:    request.method,
+		Path:      request.path,
+		Params:    request.params,
+		Body:      request.body,
+		Header:    request.header,
+		target:    response,
 	}
 
-	if res.StatusCode() > 300 {
-		err := fmt.Errorf("API Error : %s", errorResponse.Message)
+	result, err := e.chain()(ctx, call)
+
+	res := &Response[T]{
+		Success: err == nil,
+		Error:   err,
+	}
+
+	if result != nil {
+		res.Response = result.Response
+		res.Attempts = result.Attempts
+
+		if data, ok := result.Data.(*T); ok && err == nil {
+			res.Data = data
+		}
+	}
+
+	return res, err
+}
+
+// roundTrip sends call to the API, binding ctx to the underlying resty request so that
+// deadlines and cancellation propagate to the HTTP call. Each attempt waits for the
+// client's RateLimiter, if any, and failed attempts are retried according to the
+// client's RetryPolicy.
+func (e *EdgegapClient) roundTrip(ctx context.Context, call *Call) (*Result, error) {
+	result := &Result{}
+
+	for attempt := 1; ; attempt++ {
+		var errorResponse ErrorResponse
+
+		if err := e.limiter.wait(ctx, call.Path); err != nil {
+			return result, err
+		}
+
+		req := e.client.R().SetContext(ctx).SetError(&errorResponse)
+
+		if call.target != nil {
+			req.SetResult(call.target)
+		}
+
+		if call.Body != nil {
+			req.SetBody(call.Body)
+		}
 
-		return &Response[T]{
-			Success:  false,
-			Data:     nil,
-			Response: res,
+		for key, values := range call.Header {
+			req.Header[key] = values
+		}
+
+		start := time.Now()
+		res, err := req.Execute(call.Method, call.Path)
+
+		current := Attempt{
+			Number:   attempt,
+			Duration: time.Since(start),
 			Error:    err,
-		}, err
+		}
+
+		if res != nil && res.RawResponse != nil {
+			current.StatusCode = res.StatusCode()
+		}
+
+		e.limiter.observe(call.Path, res)
+
+		if err == nil && res.StatusCode() > 300 {
+			err = newAPIError(call, res.StatusCode(), errorResponse.Message, res.Body())
+			current.Error = err
+		}
+
+		delay, retry := e.retry.next(ctx, call.Method, attempt, res, err)
+		current.Delay = delay
+
+		result.Response = res
+		result.Attempts = append(result.Attempts, current)
+
+		if retry {
+			waitErr := sleepContext(ctx, delay)
+			if waitErr == nil {
+				continue
+			}
+
+			// The context ended during the backoff : it is why the call failed, the last attempt error is kept for errors.As.
+			if err != nil {
+				return result, fmt.Errorf("%w : %w", waitErr, err)
+			}
+
+			return result, waitErr
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Data = call.target
+
+		return result, nil
 	}
+}
 
-	return &Response[T]{
-		Success:  true,
-		Response: res,
-		Data:     response,
-		Error:    nil,
-	}, nil
+// sleepContext waits for d or until ctx is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
@@ -0,0 +1,311 @@
+package edgegap
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// WaitOptions controls how a deployment is polled while waiting for it.
+type WaitOptions struct {
+	Interval        time.Duration // Delay before the second poll, doubled on every following poll. Defaults to 1s
+	MaxInterval     time.Duration // Upper bound of the delay between two polls. Defaults to 10s
+	Timeout         time.Duration // Overall time allowed to wait, on top of the context deadline. 0 means no timeout
+	CrashCheckPolls int           // Polls between two crash loop checks of a starting deployment. Defaults to DefaultCrashCheckPolls, negative disables them
+}
+
+// Default number of polls between two crash loop checks, see WaitOptions.CrashCheckPolls.
+const DefaultCrashCheckPolls = 3
+
+// DeploymentStatusEvent is a change of the CurrentStatus of a deployment.
+type DeploymentStatusEvent struct {
+	Deployment DeploymentInfo // Deployment, as returned by the poll that saw the change
+	Previous   string         // Previous status, empty for the first event
+	Err        error          // Set on the last event when the wait failed, see WaitForDeployment
+}
+
+// DeploymentError is returned when a deployment ends in error or is terminated before being ready, or crash loops while
+// starting.
+type DeploymentError struct {
+	Deployment DeploymentInfo           // Last known state of the deployment
+	Logs       *DeploymentContainerLogs // Container logs and crash data, nil when they could not be retrieved
+	Report     *CrashReport             // Analysis of the crash data, nil when the logs could not be retrieved
+}
+
+func (e *DeploymentError) Error() string {
+	var message string
+
+	switch {
+	case e.Deployment.Error:
+		message = fmt.Sprintf("deployment %s failed with status %s", e.Deployment.RequestID, e.Deployment.CurrentStatus)
+	case e.Deployment.CurrentStatus == DeploymentStatusTerminated || e.Deployment.CurrentStatus == DeploymentStatusError:
+		message = fmt.Sprintf("deployment %s stopped with status %s before being ready", e.Deployment.RequestID, e.Deployment.CurrentStatus)
+	default:
+		message = fmt.Sprintf("deployment %s is not ready with status %s", e.Deployment.RequestID, e.Deployment.CurrentStatus)
+	}
+
+	if e.Logs != nil && len(e.Logs.CrashData) > 0 {
+		crash := e.Logs.CrashData[len(e.Logs.CrashData)-1]
+		message += fmt.Sprintf(" : container exited with code %d after %d restarts", crash.ExitCode, crash.RestartCount)
+
+		if crash.Message != "" {
+			message += " : " + crash.Message
+		}
+	}
+
+	if e.Report != nil && e.Report.CrashLoop {
+		message += " : crash loop"
+	}
+
+	return message
+}
+
+// SessionError is returned when a session is in error or unprocessable before being ready.
+type SessionError struct {
+	Session Session // Last known state of the session
+}
+
+func (e *SessionError) Error() string {
+	message := fmt.Sprintf("session %s failed with status %s", e.Session.ID, e.Session.Status)
+
+	if e.Session.Error != "" {
+		message += " : " + e.Session.Error
+	}
+
+	return message
+}
+
+func (o WaitOptions) policy() RetryPolicy {
+	policy := RetryPolicy{BaseDelay: o.Interval, MaxDelay: o.MaxInterval}
+
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = time.Second
+	}
+
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = 10 * time.Second
+	}
+
+	return policy
+}
+
+// Polls a deployment, backing off between polls, until it is ready, in error or terminated, and returns its final state.
+// A deployment in error or terminated before being ready returns a *DeploymentError holding its container logs.
+// The logs are also fetched every opts.CrashCheckPolls polls : a container whose restart count keeps growing returns
+// a *DeploymentError with a crash looping Report, without waiting for the deployment to fail.
+// Rate limits, server and network errors met while polling are retried; other API errors are returned as is.
+func (e *EdgegapClient) WaitForDeployment(ctx context.Context, requestID string, opts WaitOptions) (*DeploymentInfo, error) {
+	return e.pollDeployment(ctx, requestID, opts, nil, e.untilReady(ctx, requestID, opts))
+}
+
+// Polls a stopped deployment, backing off between polls, until it is terminated, and returns its final state.
+// Stopping a deployment is asynchronous : use it after Deployments.Stop to know when the deployment is gone.
+// A deployment the API does not know anymore is considered terminated, nil is then returned with no error.
+func (e *EdgegapClient) WaitForStopped(ctx context.Context, requestID string, opts WaitOptions) (*DeploymentInfo, error) {
+	info, err := e.pollDeployment(ctx, requestID, opts, nil, func(info *DeploymentInfo) (bool, error) {
+		return info.CurrentStatus == DeploymentStatusTerminated, nil
+	})
+
+	if IsNotFound(err) {
+		return nil, nil
+	}
+
+	return info, err
+}
+
+// Polls a deployment like WaitForDeployment and sends an event on the returned channel each time its status changes.
+// The channel is closed once the deployment is ready, in error or terminated, or when ctx is done.
+// When the wait fails, the last event carries the error.
+func (e *EdgegapClient) WatchDeploymentStatus(ctx context.Context, requestID string, opts WaitOptions) <-chan DeploymentStatusEvent {
+	events := make(chan DeploymentStatusEvent)
+
+	go func() {
+		defer close(events)
+
+		send := func(event DeploymentStatusEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+
+		info, err := e.pollDeployment(ctx, requestID, opts, send, e.untilReady(ctx, requestID, opts))
+
+		if err != nil {
+			event := DeploymentStatusEvent{Err: err}
+
+			if info != nil {
+				event.Deployment = *info
+			}
+
+			send(event)
+		}
+	}()
+
+	return events
+}
+
+// Polls a session, backing off between polls, until the deployment it is linked to is ready, and returns that deployment.
+// A session in error or unprocessable returns a *SessionError.
+func (e *EdgegapClient) WaitForSessionReady(ctx context.Context, sessionID string, opts WaitOptions) (*DeploymentInfo, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	policy := opts.policy()
+
+	for poll := 1; ; poll++ {
+		res, err := e.Sessions.Get(ctx, sessionID)
+
+		switch {
+		case err == nil:
+			session := res.Data
+
+			if session.Status == SessionStatusError || session.Status == SessionStatusUnprocessable {
+				return nil, &SessionError{Session: *session}
+			}
+
+			if session.Ready {
+				return &session.Deployment, nil
+			}
+		case !isTransient(err):
+			return nil, err
+		}
+
+		if err := sleepContext(ctx, policy.backoff(poll)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// untilReady reports if a polled deployment is ready, failing when it is in error, terminated or crash looping.
+func (e *EdgegapClient) untilReady(ctx context.Context, requestID string, opts WaitOptions) func(info *DeploymentInfo) (bool, error) {
+	every := cmp.Or(opts.CrashCheckPolls, DefaultCrashCheckPolls)
+	detector := &crashLoopDetector{requestID: requestID}
+	polls := 0
+
+	return func(info *DeploymentInfo) (bool, error) {
+		if info.Error || info.CurrentStatus == DeploymentStatusError || info.CurrentStatus == DeploymentStatusTerminated {
+			return true, e.deploymentError(ctx, info)
+		}
+
+		if info.Running {
+			return true, nil
+		}
+
+		polls++
+
+		if every > 0 && polls%every == 0 {
+			if err := e.checkCrashLoop(ctx, info, detector); err != nil {
+				return true, err
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// pollDeployment polls a deployment until done reports it is, calling onChange with every status change.
+// On failure, the last known state of the deployment is returned with the error, when there is one.
+func (e *EdgegapClient) pollDeployment(ctx context.Context, requestID string, opts WaitOptions, onChange func(DeploymentStatusEvent), done func(*DeploymentInfo) (bool, error)) (*DeploymentInfo, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	policy := opts.policy()
+
+	var last *DeploymentInfo
+
+	for poll := 1; ; poll++ {
+		res, err := e.Deployments.Get(ctx, requestID)
+
+		switch {
+		case err == nil:
+			info := res.Data
+
+			if onChange != nil && (last == nil || last.CurrentStatus != info.CurrentStatus) {
+				event := DeploymentStatusEvent{Deployment: *info}
+
+				if last != nil {
+					event.Previous = last.CurrentStatus
+				}
+
+				onChange(event)
+			}
+
+			last = info
+
+			if finished, err := done(info); finished {
+				return info, err
+			}
+		case !isTransient(err):
+			return last, err
+		}
+
+		if err := sleepContext(ctx, policy.backoff(poll)); err != nil {
+			return last, err
+		}
+	}
+}
+
+// deploymentError builds the error of a failed deployment, with its container logs and their analysis when they can be
+// retrieved. The analysis is recorded in the crash tracker of the client, if any.
+func (e *EdgegapClient) deploymentError(ctx context.Context, info *DeploymentInfo) error {
+	deploymentErr := &DeploymentError{Deployment: *info}
+
+	if logs, err := e.Deployments.Logs(ctx, info.RequestID); err == nil {
+		deploymentErr.Logs = logs.Data
+		deploymentErr.Report = AnalyzeCrash(info.RequestID, logs.Data)
+
+		e.crashes.record(info, deploymentErr.Report)
+	}
+
+	return deploymentErr
+}
+
+// checkCrashLoop fetches the container logs of a starting deployment, returning a *DeploymentError when detector sees it
+// crash looping. The loop is recorded in the crash tracker of the client, if any. Logs that can not be retrieved are
+// checked again on a later poll.
+func (e *EdgegapClient) checkCrashLoop(ctx context.Context, info *DeploymentInfo, detector *crashLoopDetector) error {
+	logs, err := e.Deployments.Logs(ctx, info.RequestID)
+	if err != nil {
+		return nil
+	}
+
+	report := detector.check(logs.Data)
+	if !report.CrashLoop {
+		return nil
+	}
+
+	e.crashes.record(info, report)
+
+	return &DeploymentError{Deployment: *info, Logs: logs.Data, Report: report}
+}
+
+// isTransient reports if err is worth polling again : a rate limit, a server error or a network error.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
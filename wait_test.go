@@ -0,0 +1,219 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+var fastPolls = edgegap.WaitOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func TestWaitForDeployment(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	created, err := client.Deployments.Create(ctx, &edgegap.DeployementCreatePayload{AppName: "game", VersionName: "v1"})
+	if err != nil {
+		t.Fatalf("Deployments.Create() error = %v", err)
+	}
+
+	// A server error while polling is retried.
+	server.InjectFault(edgegaptest.Fault{Path: "/status/", Status: http.StatusBadGateway, Times: 1})
+
+	info, err := client.WaitForDeployment(ctx, created.Data.RequestID, fastPolls)
+	if err != nil {
+		t.Fatalf("WaitForDeployment() error = %v", err)
+	}
+
+	if !info.Running || info.CurrentStatus != edgegap.DeploymentStatusReady {
+		t.Errorf("WaitForDeployment() = %+v, want a ready deployment", info)
+	}
+}
+
+func TestWaitForDeploymentError(t *testing.T) {
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+
+	server.SetDeploymentStatus(id, edgegaptest.StatusError)
+	server.SetContainerLogs(id, edgegap.DeploymentContainerLogs{
+		CrashData: []edgegap.ContainerCrashData{{ExitCode: 139, Message: "Segmentation fault", RestartCount: 2}},
+	})
+
+	_, err := client.WaitForDeployment(context.Background(), id, fastPolls)
+
+	var deploymentErr *edgegap.DeploymentError
+
+	if !errors.As(err, &deploymentErr) {
+		t.Fatalf("WaitForDeployment() error = %v, want a DeploymentError", err)
+	}
+
+	if deploymentErr.Logs == nil || !strings.Contains(err.Error(), "Segmentation fault") {
+		t.Errorf("WaitForDeployment() error = %v, want the crash data", err)
+	}
+}
+
+func TestWaitForDeploymentCrashLoop(t *testing.T) {
+	tracker := edgegap.NewCrashTracker(1, nil)
+	server, client := newTestClient(t, edgegap.WithCrashTracker(tracker))
+
+	id := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v1"})
+	server.SetDeploymentStatus(id, edgegaptest.StatusDeploying)
+	server.CrashLoop(id, edgegap.ContainerCrashData{ExitCode: 1, Message: "panic: missing config", RestartCount: 2})
+
+	opts := fastPolls
+	opts.CrashCheckPolls = 1
+
+	_, err := client.WaitForDeployment(context.Background(), id, opts)
+
+	var deploymentErr *edgegap.DeploymentError
+
+	if !errors.As(err, &deploymentErr) || deploymentErr.Report == nil || !deploymentErr.Report.CrashLoop {
+		t.Fatalf("WaitForDeployment() error = %v, want a crash looping DeploymentError", err)
+	}
+
+	if deploymentErr.Deployment.CurrentStatus != edgegaptest.StatusDeploying || !strings.Contains(err.Error(), "crash loop") {
+		t.Errorf("WaitForDeployment() error = %v, want it before the deployment fails", err)
+	}
+
+	if !tracker.IsFlagged("game", "v1") {
+		t.Errorf("IsFlagged() = false, want the crash loop recorded")
+	}
+
+	// Restarted long ago but stable, the container is not crash looping.
+	stable := server.AddDeployment(edgegap.DeploymentInfo{})
+	server.SetDeploymentStatus(stable, edgegaptest.StatusDeploying)
+	server.SetContainerLogs(stable, edgegap.DeploymentContainerLogs{
+		CrashData: []edgegap.ContainerCrashData{{ExitCode: 1, RestartCount: 5}},
+	})
+
+	opts.Timeout = 30 * time.Millisecond
+
+	if _, err := client.WaitForDeployment(context.Background(), stable, opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForDeployment() on a stable container error = %v, want a timeout", err)
+	}
+}
+
+func TestWaitForDeploymentFailsOnAPIErrors(t *testing.T) {
+	_, client := newTestClient(t)
+
+	if _, err := client.WaitForDeployment(context.Background(), "missing", fastPolls); !edgegap.IsNotFound(err) {
+		t.Errorf("WaitForDeployment() error = %v, want not found", err)
+	}
+}
+
+func TestWaitForDeploymentFailsOnDecodeErrors(t *testing.T) {
+	client := newStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"request_id":`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.WaitForDeployment(ctx, "9f511e17dfa4", fastPolls)
+
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForDeployment() error = %v, want the decode error without polling again", err)
+	}
+}
+
+func TestWaitForDeploymentTimeout(t *testing.T) {
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+	server.SetDeploymentStatus(id, edgegaptest.StatusDeploying)
+
+	opts := fastPolls
+	opts.Timeout = 30 * time.Millisecond
+
+	if _, err := client.WaitForDeployment(context.Background(), id, opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForDeployment() error = %v, want a timeout", err)
+	}
+}
+
+func TestWatchDeploymentStatus(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	created, err := client.Deployments.Create(ctx, &edgegap.DeployementCreatePayload{AppName: "game", VersionName: "v1"})
+	if err != nil {
+		t.Fatalf("Deployments.Create() error = %v", err)
+	}
+
+	var statuses []string
+
+	for event := range client.WatchDeploymentStatus(ctx, created.Data.RequestID, fastPolls) {
+		if event.Err != nil {
+			t.Fatalf("WatchDeploymentStatus() error = %v", event.Err)
+		}
+
+		if len(statuses) > 0 && event.Previous != statuses[len(statuses)-1] {
+			t.Errorf("Previous = %q, want %q", event.Previous, statuses[len(statuses)-1])
+		}
+
+		statuses = append(statuses, event.Deployment.CurrentStatus)
+	}
+
+	want := []string{edgegap.DeploymentStatusSeeking, edgegap.DeploymentStatusDeploying, edgegap.DeploymentStatusReady}
+
+	if strings.Join(statuses, ",") != strings.Join(want, ",") {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+}
+
+func TestWatchDeploymentStatusError(t *testing.T) {
+	server, client := newTestClient(t)
+	id := server.AddDeployment(edgegap.DeploymentInfo{})
+	server.SetDeploymentStatus(id, edgegaptest.StatusError)
+
+	var last edgegap.DeploymentStatusEvent
+
+	for event := range client.WatchDeploymentStatus(context.Background(), id, fastPolls) {
+		last = event
+	}
+
+	var deploymentErr *edgegap.DeploymentError
+
+	if !errors.As(last.Err, &deploymentErr) || deploymentErr.Deployment.RequestID != id {
+		t.Errorf("last event = %+v, want a DeploymentError", last)
+	}
+}
+
+func TestWaitForSessionReady(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	created, err := client.Sessions.Create(ctx, &edgegap.SessionCreate{App: "game", IPList: []string{"1.2.3.4"}})
+	if err != nil {
+		t.Fatalf("Sessions.Create() error = %v", err)
+	}
+
+	info, err := client.WaitForSessionReady(ctx, created.Data.SessionID, fastPolls)
+	if err != nil {
+		t.Fatalf("WaitForSessionReady() error = %v", err)
+	}
+
+	if info.RequestID != created.Data.DeploymentRequestId || !info.Running {
+		t.Errorf("WaitForSessionReady() = %+v, want the ready deployment %s", info, created.Data.DeploymentRequestId)
+	}
+
+	failed, _ := client.Sessions.Create(ctx, &edgegap.SessionCreate{App: "game"})
+	server.SetSessionStatus(failed.Data.SessionID, edgegaptest.SessionStatusError, "No deployment available")
+
+	_, err = client.WaitForSessionReady(ctx, failed.Data.SessionID, fastPolls)
+
+	var sessionErr *edgegap.SessionError
+
+	if !errors.As(err, &sessionErr) || !strings.Contains(err.Error(), "No deployment available") {
+		t.Errorf("WaitForSessionReady() error = %v, want a SessionError", err)
+	}
+}
@@ -0,0 +1,601 @@
+package edgegap
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DeploymentEventType is the kind of change a DeploymentEvent reports.
+type DeploymentEventType string
+
+const (
+	DeploymentAdded               = DeploymentEventType("added")                 // The deployment appeared
+	DeploymentRemoved             = DeploymentEventType("removed")               // The deployment disappeared, Deployment holds its last known state
+	DeploymentReadyChanged        = DeploymentEventType("ready_changed")         // Deployment.Ready changed
+	DeploymentSocketsUsageChanged = DeploymentEventType("sockets_usage_changed") // Deployment.SocketsUsage changed
+	DeploymentTagsChanged         = DeploymentEventType("tags_changed")          // Deployment.Tags changed, whatever their order
+)
+
+// DeploymentEvent is a change of the deployments seen by a DeploymentWatcher.
+type DeploymentEvent struct {
+	Type       DeploymentEventType
+	Deployment Deployment  // Current state of the deployment, or its last known state when removed
+	Previous   *Deployment // Previous state of the deployment, nil when added
+}
+
+// DeploymentWatcherOptions controls how a DeploymentWatcher polls and delivers its events.
+type DeploymentWatcherOptions struct {
+	Interval     time.Duration               // Delay between two resyncs in Run. Defaults to 10s
+	BufferSize   int                         // Size of the Events channel. Defaults to 64
+	DropOnFull   bool                        // If events are dropped when the Events channel is full, instead of waiting for room
+	Handler      func(event DeploymentEvent) // Called with every event instead of sending it on the Events channel
+	OnError      func(err error)             // Called with the errors of the resyncs that Run retries, and of the crash checks
+	CrashTracker *CrashTracker               // Records the crashes of the deployments given to Update while Run is running, when set. See DeploymentWatcher
+}
+
+// DeploymentWatcher keeps a snapshot of the live deployments, indexed by request ID, and emits an event for every change.
+//
+// The snapshot is refreshed by listing every deployment, in Run or with Resync, and can be fed in between with the
+// deployments received by a WebhookHandler, Update having the signature of WebhookHandler.OnDeployment.
+//
+// Events are sent on the Events channel, or to DeploymentWatcherOptions.Handler. When the consumer is slower than the
+// changes, the watcher waits for room in the channel, which delays the resyncs, unless DropOnFull is set. Dropped events
+// are counted by Dropped : the consumer can then rebuild its state from Snapshot.
+//
+// With a CrashTracker, the container logs of the deployments given to Update are analyzed : those of a deployment in
+// error or terminated, and those of a starting deployment, recorded once its restart count keeps growing like in
+// EdgegapClient.WaitForDeployment. Update only queues these checks, so a webhook is answered without waiting for the
+// logs : they are made in the background by Run. When the queue is full, the check is dropped and reported to OnError.
+type DeploymentWatcher struct {
+	*watcher[Deployment, DeploymentEvent]
+
+	client      *EdgegapClient
+	crashes     *CrashTracker
+	crashChecks chan DeploymentInfo // Deployments given to Update whose crashes are checked by Run
+
+	detectorsMu sync.Mutex
+	detectors   map[string]*crashLoopDetector // Crash loop detectors of the starting deployments, by request ID
+}
+
+// Creates a watcher of the deployments. Start it with Run, or feed it with Resync and Update.
+func (e *EdgegapClient) NewDeploymentWatcher(opts DeploymentWatcherOptions) *DeploymentWatcher {
+	w := &DeploymentWatcher{
+		watcher: newWatcher(watcherOptions[DeploymentEvent]{
+			interval:   opts.Interval,
+			bufferSize: opts.BufferSize,
+			dropOnFull: opts.DropOnFull,
+			handler:    opts.Handler,
+			onError:    opts.OnError,
+		}, diffDeployment),
+		client:    e,
+		crashes:   opts.CrashTracker,
+		detectors: map[string]*crashLoopDetector{},
+	}
+
+	if w.crashes != nil {
+		w.crashChecks = make(chan DeploymentInfo, w.opts.bufferSize)
+	}
+
+	return w
+}
+
+// Resyncs the deployments every Interval until ctx is done, then closes the Events channel.
+// Rate limits, server and network errors are passed to OnError and retried on the next resync; other errors are returned.
+// With a CrashTracker, the crash checks queued by Update are made in the background until Run returns.
+func (w *DeploymentWatcher) Run(ctx context.Context) error {
+	if w.crashChecks == nil {
+		return w.run(ctx, w.Resync)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		w.checkCrashes(ctx)
+	}()
+
+	err := w.run(ctx, w.Resync)
+
+	// Stops the crash checks, waiting for the one in progress.
+	cancel()
+	wg.Wait()
+
+	return err
+}
+
+// Lists every deployment and replaces the snapshot with them, emitting the changes.
+// On error the snapshot is left untouched, so deployments are not reported removed because a list failed.
+func (w *DeploymentWatcher) Resync(ctx context.Context) error {
+	listed := map[string]Deployment{}
+
+	for deployment, err := range w.client.Deployments.Iterate(ctx) {
+		if err != nil {
+			return err
+		}
+
+		listed[deployment.RequestID] = deployment
+	}
+
+	w.detectorsMu.Lock()
+	maps.DeleteFunc(w.detectors, func(requestID string, _ *crashLoopDetector) bool {
+		_, ok := listed[requestID]
+		return !ok
+	})
+	w.detectorsMu.Unlock()
+
+	return w.replace(ctx, listed)
+}
+
+// Applies a deployment received from a webhook, emitting its changes. A deployment in error or terminated is removed.
+// With a CrashTracker, the crash check of the deployment is queued for Run.
+func (w *DeploymentWatcher) Update(ctx context.Context, info DeploymentInfo) error {
+	if w.crashChecks != nil {
+		select {
+		case w.crashChecks <- info:
+		default:
+			if w.opts.onError != nil {
+				w.opts.onError(fmt.Errorf("edgegap: crash check of deployment %s dropped, the queue is full", info.RequestID))
+			}
+		}
+	}
+
+	return w.update(ctx, info.RequestID, func(before Deployment, known bool) *Deployment {
+		if info.Error || info.CurrentStatus == DeploymentStatusError || info.CurrentStatus == DeploymentStatusTerminated {
+			return nil
+		}
+
+		joinable := true
+		if known {
+			joinable = before.IsJoinableBySession
+		}
+
+		deployment := deploymentFromInfo(info, joinable)
+
+		return &deployment
+	})
+}
+
+// checkCrashes makes the crash checks queued by Update, one at a time, until ctx is done.
+func (w *DeploymentWatcher) checkCrashes(ctx context.Context) {
+	for {
+		select {
+		case info := <-w.crashChecks:
+			w.trackCrashes(ctx, info)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// trackCrashes analyzes the container logs of a deployment given to Update and records its crashes in the crash tracker.
+// Logs that can not be retrieved are passed to OnError.
+func (w *DeploymentWatcher) trackCrashes(ctx context.Context, info DeploymentInfo) {
+	failed := info.Error || info.CurrentStatus == DeploymentStatusError || info.CurrentStatus == DeploymentStatusTerminated
+
+	w.detectorsMu.Lock()
+	detector := w.detectors[info.RequestID]
+
+	switch {
+	case failed || info.Running:
+		delete(w.detectors, info.RequestID)
+	case detector == nil:
+		detector = &crashLoopDetector{requestID: info.RequestID}
+		w.detectors[info.RequestID] = detector
+	}
+	w.detectorsMu.Unlock()
+
+	if info.Running {
+		return
+	}
+
+	logs, err := w.client.Deployments.Logs(ctx, info.RequestID)
+	if err != nil {
+		if w.opts.onError != nil {
+			w.opts.onError(err)
+		}
+
+		return
+	}
+
+	if failed {
+		w.crashes.record(&info, AnalyzeCrash(info.RequestID, logs.Data))
+		return
+	}
+
+	w.detectorsMu.Lock()
+	report := detector.check(logs.Data)
+	w.detectorsMu.Unlock()
+
+	if report.CrashLoop {
+		w.crashes.record(&info, report)
+	}
+}
+
+// diffDeployment returns the events describing the changes from before to after, either being nil when the deployment is added or removed.
+func diffDeployment(before, after *Deployment) []DeploymentEvent {
+	switch {
+	case before == nil:
+		return []DeploymentEvent{{Type: DeploymentAdded, Deployment: *after}}
+	case after == nil:
+		return []DeploymentEvent{{Type: DeploymentRemoved, Deployment: *before, Previous: before}}
+	}
+
+	var events []DeploymentEvent
+
+	if before.Ready != after.Ready {
+		events = append(events, DeploymentEvent{Type: DeploymentReadyChanged, Deployment: *after, Previous: before})
+	}
+
+	if before.SocketsUsage != after.SocketsUsage {
+		events = append(events, DeploymentEvent{Type: DeploymentSocketsUsageChanged, Deployment: *after, Previous: before})
+	}
+
+	if !sameTags(before.Tags, after.Tags) {
+		events = append(events, DeploymentEvent{Type: DeploymentTagsChanged, Deployment: *after, Previous: before})
+	}
+
+	return events
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
+
+// deploymentFromInfo converts the detailed state of a deployment to the summary returned by the list endpoint.
+func deploymentFromInfo(info DeploymentInfo, joinable bool) Deployment {
+	return Deployment{
+		RequestID:           info.RequestID,
+		FQDN:                info.FDQN,
+		StartTime:           info.StartTime,
+		Ready:               info.Running,
+		PublicIP:            info.PublicIP,
+		Ports:               info.Ports,
+		Tags:                info.Tags,
+		Sockets:             strconv.Itoa(info.Sockets),
+		SocketsUsage:        strconv.Itoa(info.SocketsUsage),
+		IsJoinableBySession: joinable,
+	}
+}
+
+// SessionEventType is the kind of change a SessionEvent reports.
+type SessionEventType string
+
+const (
+	SessionAdded            = SessionEventType("added")              // The session appeared
+	SessionRemoved          = SessionEventType("removed")            // The session disappeared, Session holds its last known state
+	SessionLinked           = SessionEventType("linked")             // The session got linked to a deployment
+	SessionReady            = SessionEventType("ready")              // The deployment of the session got ready
+	SessionFailed           = SessionEventType("failed")             // The session went in error or unprocessable, see Session.Error
+	SessionUserCountChanged = SessionEventType("user_count_changed") // Session.UserCount changed
+)
+
+// SessionEvent is a change of the sessions seen by a SessionWatcher.
+type SessionEvent struct {
+	Type     SessionEventType
+	Session  Session  // Current state of the session, or its last known state when removed
+	Previous *Session // Previous state of the session, nil when added
+}
+
+// SessionWatcherOptions controls how a SessionWatcher polls and delivers its events.
+type SessionWatcherOptions struct {
+	Interval   time.Duration            // Delay between two resyncs in Run. Defaults to 10s
+	BufferSize int                      // Size of the Events channel. Defaults to 64
+	DropOnFull bool                     // If events are dropped when the Events channel is full, instead of waiting for room
+	Handler    func(event SessionEvent) // Called with every event instead of sending it on the Events channel
+	OnError    func(err error)          // Called with the errors of the resyncs that Run retries
+}
+
+// SessionWatcher keeps a snapshot of the sessions, indexed by ID, and emits an event for every step of their lifecycle.
+//
+// The snapshot is refreshed by listing every session, in Run or with Resync, and can be fed in between with Refresh
+// or with the sessions received by a WebhookHandler, Update having the signature of WebhookHandler.OnSession.
+// Events are delivered like the ones of a DeploymentWatcher.
+type SessionWatcher struct {
+	*watcher[Session, SessionEvent]
+
+	client *EdgegapClient
+}
+
+// Creates a watcher of the sessions. Start it with Run, or feed it with Resync, Refresh and Update.
+func (e *EdgegapClient) NewSessionWatcher(opts SessionWatcherOptions) *SessionWatcher {
+	return &SessionWatcher{
+		watcher: newWatcher(watcherOptions[SessionEvent]{
+			interval:   opts.Interval,
+			bufferSize: opts.BufferSize,
+			dropOnFull: opts.DropOnFull,
+			handler:    opts.Handler,
+			onError:    opts.OnError,
+		}, diffSession),
+		client: e,
+	}
+}
+
+// Resyncs the sessions every Interval until ctx is done, then closes the Events channel.
+// Rate limits, server and network errors are passed to OnError and retried on the next resync; other errors are returned.
+func (w *SessionWatcher) Run(ctx context.Context) error {
+	return w.run(ctx, w.Resync)
+}
+
+// Lists every session and replaces the snapshot with them, emitting the changes.
+// On error the snapshot is left untouched, so sessions are not reported removed because a list failed.
+func (w *SessionWatcher) Resync(ctx context.Context) error {
+	listed := map[string]Session{}
+
+	for session, err := range w.client.Sessions.Iterate(ctx) {
+		if err != nil {
+			return err
+		}
+
+		listed[session.ID] = session
+	}
+
+	return w.replace(ctx, listed)
+}
+
+// Retrieves a single session and applies it, emitting its changes. A session the API does not know anymore is removed.
+func (w *SessionWatcher) Refresh(ctx context.Context, id string) error {
+	res, err := w.client.Sessions.Get(ctx, id)
+
+	switch {
+	case IsNotFound(err):
+		return w.update(ctx, id, func(Session, bool) *Session { return nil })
+	case err != nil:
+		return err
+	}
+
+	return w.Update(ctx, *res.Data)
+}
+
+// Applies a session received from a webhook, emitting its changes.
+func (w *SessionWatcher) Update(ctx context.Context, session Session) error {
+	return w.update(ctx, session.ID, func(Session, bool) *Session {
+		return &session
+	})
+}
+
+// diffSession returns the events describing the changes from before to after, either being nil when the session is added or removed.
+func diffSession(before, after *Session) []SessionEvent {
+	switch {
+	case before == nil:
+		return []SessionEvent{{Type: SessionAdded, Session: *after}}
+	case after == nil:
+		return []SessionEvent{{Type: SessionRemoved, Session: *before, Previous: before}}
+	}
+
+	var events []SessionEvent
+
+	if !before.Linked && after.Linked {
+		events = append(events, SessionEvent{Type: SessionLinked, Session: *after, Previous: before})
+	}
+
+	if !before.Ready && after.Ready {
+		events = append(events, SessionEvent{Type: SessionReady, Session: *after, Previous: before})
+	}
+
+	if !sessionFailed(before) && sessionFailed(after) {
+		events = append(events, SessionEvent{Type: SessionFailed, Session: *after, Previous: before})
+	}
+
+	if before.UserCount != after.UserCount {
+		events = append(events, SessionEvent{Type: SessionUserCountChanged, Session: *after, Previous: before})
+	}
+
+	return events
+}
+
+// sessionFailed reports if a session is in error or could not be processed.
+func sessionFailed(session *Session) bool {
+	return session.Status == SessionStatusError || session.Status == SessionStatusUnprocessable
+}
+
+type watcherOptions[E any] struct {
+	interval   time.Duration
+	bufferSize int
+	dropOnFull bool
+	handler    func(event E)
+	onError    func(err error)
+}
+
+// watcher is the snapshot and event delivery shared by the watchers, T being the watched items and E their events.
+type watcher[T any, E any] struct {
+	opts    watcherOptions[E]
+	diff    func(before, after *T) []E
+	events  chan E
+	dropped atomic.Int64
+
+	emitMu sync.Mutex // Serializes the diffs and their events, so events are emitted in order
+	closed bool
+
+	mu    sync.RWMutex
+	items map[string]T
+}
+
+func newWatcher[T any, E any](opts watcherOptions[E], diff func(before, after *T) []E) *watcher[T, E] {
+	if opts.interval <= 0 {
+		opts.interval = 10 * time.Second
+	}
+
+	if opts.bufferSize <= 0 {
+		opts.bufferSize = 64
+	}
+
+	return &watcher[T, E]{
+		opts:   opts,
+		diff:   diff,
+		events: make(chan E, opts.bufferSize),
+		items:  map[string]T{},
+	}
+}
+
+// Returns the channel the events are sent on. It is closed when Run returns.
+func (w *watcher[T, E]) Events() <-chan E {
+	return w.events
+}
+
+// Returns the number of events dropped because the Events channel was full.
+func (w *watcher[T, E]) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Returns a copy of the items currently known, by ID.
+func (w *watcher[T, E]) Snapshot() map[string]T {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return maps.Clone(w.items)
+}
+
+// Returns an item currently known.
+func (w *watcher[T, E]) Get(id string) (T, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	item, ok := w.items[id]
+
+	return item, ok
+}
+
+// run calls resync every interval until ctx is done, then closes the events channel.
+func (w *watcher[T, E]) run(ctx context.Context, resync func(ctx context.Context) error) error {
+	defer w.close()
+
+	for {
+		if err := resync(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if !isTransient(err) {
+				return err
+			}
+
+			if w.opts.onError != nil {
+				w.opts.onError(err)
+			}
+		}
+
+		if err := sleepContext(ctx, w.opts.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// replace replaces every item with listed, emitting the changes in the order of the IDs.
+func (w *watcher[T, E]) replace(ctx context.Context, listed map[string]T) error {
+	w.emitMu.Lock()
+	defer w.emitMu.Unlock()
+
+	w.mu.Lock()
+	previous := w.items
+	w.items = listed
+	w.mu.Unlock()
+
+	var events []E
+
+	for _, id := range slices.Sorted(maps.Keys(listed)) {
+		after := listed[id]
+
+		if before, ok := previous[id]; ok {
+			events = append(events, w.diff(&before, &after)...)
+		} else {
+			events = append(events, w.diff(nil, &after)...)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := listed[id]; !ok {
+			before := previous[id]
+			events = append(events, w.diff(&before, nil)...)
+		}
+	}
+
+	return w.emit(ctx, events)
+}
+
+// update replaces an item with the result of fn, called with its current state, emitting the changes. A nil result removes it.
+func (w *watcher[T, E]) update(ctx context.Context, id string, fn func(before T, known bool) *T) error {
+	w.emitMu.Lock()
+	defer w.emitMu.Unlock()
+
+	w.mu.Lock()
+	before, known := w.items[id]
+	after := fn(before, known)
+
+	if after != nil {
+		w.items[id] = *after
+	} else {
+		delete(w.items, id)
+	}
+	w.mu.Unlock()
+
+	var events []E
+
+	switch {
+	case known:
+		events = w.diff(&before, after)
+	case after != nil:
+		events = w.diff(nil, after)
+	}
+
+	return w.emit(ctx, events)
+}
+
+// emit delivers events, in order. Must be called with emitMu held.
+func (w *watcher[T, E]) emit(ctx context.Context, events []E) error {
+	for _, event := range events {
+		if w.opts.handler != nil {
+			w.opts.handler(event)
+			continue
+		}
+
+		if w.closed {
+			continue
+		}
+
+		if w.opts.dropOnFull {
+			select {
+			case w.events <- event:
+			default:
+				w.dropped.Add(1)
+			}
+
+			continue
+		}
+
+		select {
+		case w.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (w *watcher[T, E]) close() {
+	w.emitMu.Lock()
+	defer w.emitMu.Unlock()
+
+	if !w.closed {
+		w.closed = true
+		close(w.events)
+	}
+}
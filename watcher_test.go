@@ -0,0 +1,374 @@
+package edgegap_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kisshan13/go-edgegap"
+	"github.com/kisshan13/go-edgegap/edgegaptest"
+)
+
+// eventTypes returns the types of events, in order.
+func eventTypes(events []edgegap.DeploymentEvent) []edgegap.DeploymentEventType {
+	types := make([]edgegap.DeploymentEventType, len(events))
+
+	for i, event := range events {
+		types[i] = event.Type
+	}
+
+	return types
+}
+
+func TestDeploymentWatcherResync(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+
+	var events []edgegap.DeploymentEvent
+
+	watcher := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{
+		Handler: func(event edgegap.DeploymentEvent) { events = append(events, event) },
+	})
+
+	ready := server.AddDeployment(edgegap.DeploymentInfo{RequestID: "a"})
+	deploying := server.AddDeployment(edgegap.DeploymentInfo{RequestID: "b", CurrentStatus: edgegaptest.StatusDeploying})
+
+	if err := watcher.Resync(ctx); err != nil {
+		t.Fatalf("Resync() error = %v", err)
+	}
+
+	if got := eventTypes(events); len(got) != 2 || got[0] != edgegap.DeploymentAdded || got[1] != edgegap.DeploymentAdded {
+		t.Fatalf("first Resync() events = %v, want two added", got)
+	}
+
+	events = nil
+	server.SetDeploymentStatus(deploying, edgegaptest.StatusReady)
+	server.SetDeploymentStatus(ready, edgegaptest.StatusTerminated)
+
+	if err := watcher.Resync(ctx); err != nil {
+		t.Fatalf("Resync() error = %v", err)
+	}
+
+	want := []edgegap.DeploymentEventType{edgegap.DeploymentReadyChanged, edgegap.DeploymentRemoved}
+
+	if got := eventTypes(events); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("second Resync() events = %v, want %v", got, want)
+	}
+
+	if events[0].Previous == nil || events[0].Previous.Ready || !events[0].Deployment.Ready {
+		t.Errorf("ReadyChanged event = %+v, want not ready to ready", events[0])
+	}
+
+	if snapshot := watcher.Snapshot(); len(snapshot) != 1 || !snapshot[deploying].Ready {
+		t.Errorf("Snapshot() = %+v, want only the ready deployment %s", snapshot, deploying)
+	}
+
+	// A failed list leaves the snapshot untouched.
+	server.InjectFault(edgegaptest.Fault{Path: "/deployments", Status: 503, Times: 1})
+
+	if err := watcher.Resync(ctx); err == nil {
+		t.Error("Resync() with a failing API succeeded")
+	}
+
+	if _, ok := watcher.Get(deploying); !ok {
+		t.Errorf("Get(%s) after a failed resync found nothing", deploying)
+	}
+}
+
+func TestDeploymentWatcherUpdate(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	var events []edgegap.DeploymentEvent
+
+	watcher := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{
+		Handler: func(event edgegap.DeploymentEvent) { events = append(events, event) },
+	})
+
+	// Update has the signature of the webhook callbacks.
+	webhook, err := edgegap.NewWebhookHandler("s3cret")
+	if err != nil {
+		t.Fatalf("NewWebhookHandler() error = %v", err)
+	}
+	webhook.OnDeployment(watcher.Update)
+
+	info := edgegap.DeploymentInfo{RequestID: "a", CurrentStatus: edgegap.DeploymentStatusReady, Running: true, Tags: []string{"eu", "ranked"}}
+
+	updates := []func(){
+		func() {},
+		func() { info.SocketsUsage = 4 },
+		func() { info.Tags = []string{"ranked", "eu"} },
+		func() { info.Tags = []string{"ranked"} },
+		func() { info.CurrentStatus = edgegap.DeploymentStatusTerminated },
+	}
+
+	for _, update := range updates {
+		update()
+
+		if err := watcher.Update(ctx, info); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	want := []edgegap.DeploymentEventType{edgegap.DeploymentAdded, edgegap.DeploymentSocketsUsageChanged, edgegap.DeploymentTagsChanged, edgegap.DeploymentRemoved}
+	got := eventTypes(events)
+
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("events[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+// logsRequests returns the number of container logs requests received by server.
+func logsRequests(server *edgegaptest.Server) int {
+	count := 0
+
+	for _, request := range server.Requests() {
+		if strings.HasSuffix(request.Path, "/container-logs") {
+			count++
+		}
+	}
+
+	return count
+}
+
+// waitUntil polls condition until it is true, failing the test after a second.
+func waitUntil(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !condition(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met after 1s")
+		}
+	}
+}
+
+// runWatcher runs watcher until the end of the test.
+func runWatcher(t *testing.T, watcher *edgegap.DeploymentWatcher) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- watcher.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestDeploymentWatcherCrashTracker(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	tracker := edgegap.NewCrashTracker(2, nil)
+
+	watcher := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{
+		Interval:     time.Hour,
+		Handler:      func(edgegap.DeploymentEvent) {},
+		CrashTracker: tracker,
+	})
+
+	failed := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v2", CurrentStatus: edgegaptest.StatusError})
+	server.SetContainerLogs(failed, edgegap.DeploymentContainerLogs{
+		CrashData: []edgegap.ContainerCrashData{{ExitCode: 139, RestartCount: 5}},
+	})
+
+	looping := server.AddDeployment(edgegap.DeploymentInfo{AppName: "game", AppVersion: "v2", CurrentStatus: edgegaptest.StatusDeploying})
+	server.CrashLoop(looping, edgegap.ContainerCrashData{ExitCode: 1, RestartCount: 2})
+
+	runWatcher(t, watcher)
+
+	infos := []edgegap.DeploymentInfo{
+		{RequestID: failed, AppName: "game", AppVersion: "v2", CurrentStatus: edgegap.DeploymentStatusError, Error: true},
+		{RequestID: looping, AppName: "game", AppVersion: "v2", CurrentStatus: edgegap.DeploymentStatusDeploying},
+	}
+
+	for _, info := range infos {
+		if err := watcher.Update(ctx, info); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	waitUntil(t, func() bool { return logsRequests(server) == 2 })
+
+	if tracker.IsFlagged("game", "v2") {
+		t.Fatalf("IsFlagged() = true after the first check of the starting deployment, want its restarts to grow first")
+	}
+
+	if err := watcher.Update(ctx, infos[1]); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	waitUntil(t, func() bool { return tracker.IsFlagged("game", "v2") })
+
+	if versions := tracker.Flagged(); len(versions) != 1 || len(versions[0].Reports) != 2 {
+		t.Errorf("Flagged() = %+v, want game v2 with 2 reports", versions)
+	}
+}
+
+func TestDeploymentWatcherUpdateQueuesCrashChecks(t *testing.T) {
+	server, client := newTestClient(t)
+
+	watcher := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{
+		Interval:     time.Hour,
+		Handler:      func(edgegap.DeploymentEvent) {},
+		CrashTracker: edgegap.NewCrashTracker(1, nil),
+	})
+
+	id := server.AddDeployment(edgegap.DeploymentInfo{CurrentStatus: edgegaptest.StatusError})
+
+	if err := watcher.Update(context.Background(), edgegap.DeploymentInfo{RequestID: id, CurrentStatus: edgegap.DeploymentStatusError}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// The webhook is answered without waiting for the logs.
+	if count := logsRequests(server); count != 0 {
+		t.Fatalf("Update() requested the logs %d times, want the check queued", count)
+	}
+
+	runWatcher(t, watcher)
+
+	waitUntil(t, func() bool { return logsRequests(server) == 1 })
+}
+
+func TestDeploymentWatcherBackpressure(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+
+	for _, id := range []string{"a", "b", "c"} {
+		server.AddDeployment(edgegap.DeploymentInfo{RequestID: id})
+	}
+
+	watcher := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{BufferSize: 1, DropOnFull: true})
+
+	if err := watcher.Resync(ctx); err != nil {
+		t.Fatalf("Resync() error = %v", err)
+	}
+
+	if watcher.Dropped() != 2 {
+		t.Errorf("Dropped() = %d, want 2", watcher.Dropped())
+	}
+
+	if len(watcher.Snapshot()) != 3 {
+		t.Errorf("Snapshot() has %d deployments, want 3", len(watcher.Snapshot()))
+	}
+
+	// Without DropOnFull, the watcher waits for room until its context is done.
+	blocking := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{BufferSize: 1})
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+
+	if err := blocking.Resync(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Resync() with a full channel error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDeploymentWatcherRun(t *testing.T) {
+	server, client := newTestClient(t)
+	server.AddDeployment(edgegap.DeploymentInfo{RequestID: "a"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := client.NewDeploymentWatcher(edgegap.DeploymentWatcherOptions{Interval: time.Millisecond})
+
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+
+	if event := <-watcher.Events(); event.Type != edgegap.DeploymentAdded || event.Deployment.RequestID != "a" {
+		t.Errorf("first event = %+v, want a added", event)
+	}
+
+	server.AddDeployment(edgegap.DeploymentInfo{RequestID: "b"})
+
+	if event := <-watcher.Events(); event.Type != edgegap.DeploymentAdded || event.Deployment.RequestID != "b" {
+		t.Errorf("second event = %+v, want b added", event)
+	}
+
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+
+	if _, open := <-watcher.Events(); open {
+		t.Error("Events() is still open after Run returned")
+	}
+}
+
+func TestSessionWatcher(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t)
+	seedApp(server)
+
+	var events []edgegap.SessionEvent
+
+	watcher := client.NewSessionWatcher(edgegap.SessionWatcherOptions{
+		Handler: func(event edgegap.SessionEvent) { events = append(events, event) },
+	})
+
+	created, err := client.Sessions.Create(ctx, &edgegap.SessionCreate{App: "game", IPList: []string{"1.2.3.4"}})
+	if err != nil {
+		t.Fatalf("Sessions.Create() error = %v", err)
+	}
+
+	id := created.Data.SessionID
+
+	if err := watcher.Resync(ctx); err != nil {
+		t.Fatalf("Resync() error = %v", err)
+	}
+
+	if _, err := client.WaitForSessionReady(ctx, id, fastPolls); err != nil {
+		t.Fatalf("WaitForSessionReady() error = %v", err)
+	}
+
+	if _, err := client.Sessions.Users.Add(ctx, id, []string{"5.6.7.8"}); err != nil {
+		t.Fatalf("Sessions.Users.Add() error = %v", err)
+	}
+
+	if err := watcher.Refresh(ctx, id); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	server.SetSessionStatus(id, edgegaptest.SessionStatusError, "Deployment crashed")
+
+	if err := watcher.Refresh(ctx, id); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if _, err := client.Sessions.Delete(ctx, id); err != nil {
+		t.Fatalf("Sessions.Delete() error = %v", err)
+	}
+
+	if err := watcher.Refresh(ctx, id); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	want := []edgegap.SessionEventType{
+		edgegap.SessionAdded,
+		edgegap.SessionReady,
+		edgegap.SessionUserCountChanged,
+		edgegap.SessionFailed,
+		edgegap.SessionRemoved,
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("%d events, want %v", len(events), want)
+	}
+
+	for i := range want {
+		if events[i].Type != want[i] {
+			t.Errorf("events[%d] = %s, want %s", i, events[i].Type, want[i])
+		}
+	}
+
+	if changed := events[2]; changed.Previous == nil || changed.Previous.UserCount != 1 || changed.Session.UserCount != 2 {
+		t.Errorf("UserCountChanged event = %+v, want 1 to 2 users", changed)
+	}
+}
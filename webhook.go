@@ -0,0 +1,291 @@
+package edgegap
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// WebhookKind is the kind of callback a webhook URL receives.
+type WebhookKind string
+
+const (
+	WebhookDeployment = WebhookKind("deployment") // DeployementCreatePayload.WebhookURL, receiving a DeploymentInfo
+	WebhookSession    = WebhookKind("session")    // SessionCreate.WebhookURL, receiving a Session
+	WebhookTelemetry  = WebhookKind("telemetry")  // TelemetryCreate.WebhookURL, receiving a Telemetry
+)
+
+// Query parameters of the webhook URLs.
+const (
+	webhookParamToken = "token"
+	webhookParamKind  = "kind"
+)
+
+// ErrEmptyWebhookSecret is returned by NewWebhookHandler when the secret is empty, as anyone could then post callbacks.
+var ErrEmptyWebhookSecret = errors.New("edgegap: empty webhook secret")
+
+// Default number of callbacks remembered to drop redeliveries, see WithWebhookDedupSize.
+const DefaultWebhookDedupSize = 1024
+
+// Default maximum size of a callback body, see WithWebhookMaxBodySize.
+const DefaultWebhookMaxBodySize = 1 << 20
+
+// WebhookHandler is an http.Handler receiving the callbacks Edgegap POSTs to webhook URLs,
+// decoding them and dispatching them to the callbacks registered with OnDeployment, OnSession and OnTelemetry.
+//
+// The URLs given to Edgegap are built with URL : they carry the kind of callback and a shared secret token,
+// requests without the token are rejected. A callback delivered again with the same body is acknowledged
+// without being dispatched a second time.
+//
+// It answers :
+//   - 204 once the callback is dispatched, or when it is a redelivery
+//   - 400 when the kind is unknown or the body can not be decoded
+//   - 401 when the token is missing or wrong
+//   - 404 when no callback is registered for the kind
+//   - 405 when the method is not POST
+//   - 413 when the body is too large
+//   - 500 when the callback returns an error, so Edgegap delivers it again
+type WebhookHandler struct {
+	secret      string
+	dedupSize   int
+	maxBodySize int64
+
+	mu           sync.Mutex
+	onDeployment func(ctx context.Context, info DeploymentInfo) error
+	onSession    func(ctx context.Context, session Session) error
+	onTelemetry  func(ctx context.Context, telemetry Telemetry) error
+	seen         map[[sha256.Size]byte]bool
+	order        [][sha256.Size]byte
+}
+
+// WebhookOption configures a WebhookHandler created with NewWebhookHandler.
+type WebhookOption func(*WebhookHandler)
+
+// Sets the number of callbacks remembered to drop redeliveries. 0 disables the deduplication.
+func WithWebhookDedupSize(size int) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.dedupSize = size
+	}
+}
+
+// Sets the maximum size of a callback body, in bytes.
+func WithWebhookMaxBodySize(size int64) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.maxBodySize = size
+	}
+}
+
+// Creates a handler accepting the callbacks carrying secret. An empty secret returns ErrEmptyWebhookSecret.
+func NewWebhookHandler(secret string, opts ...WebhookOption) (*WebhookHandler, error) {
+	if secret == "" {
+		return nil, ErrEmptyWebhookSecret
+	}
+
+	h := &WebhookHandler{
+		secret:      secret,
+		dedupSize:   DefaultWebhookDedupSize,
+		maxBodySize: DefaultWebhookMaxBodySize,
+		seen:        map[[sha256.Size]byte]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// Registers the callback called with the deployments POSTed to the WebhookDeployment URL.
+func (h *WebhookHandler) OnDeployment(fn func(ctx context.Context, info DeploymentInfo) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onDeployment = fn
+}
+
+// Registers the callback called with the sessions POSTed to the WebhookSession URL.
+func (h *WebhookHandler) OnSession(fn func(ctx context.Context, session Session) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onSession = fn
+}
+
+// Registers the callback called with the telemetry results POSTed to the WebhookTelemetry URL.
+func (h *WebhookHandler) OnTelemetry(fn func(ctx context.Context, telemetry Telemetry) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onTelemetry = fn
+}
+
+// Returns the webhook URL to give to Edgegap for kind, baseURL being the public URL the handler is served at.
+func (h *WebhookHandler) URL(baseURL string, kind WebhookKind) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("edgegap: invalid webhook base URL : %w", err)
+	}
+
+	query := u.Query()
+	query.Set(webhookParamKind, string(kind))
+	query.Set(webhookParamToken, h.secret)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.URL.Query().Get(webhookParamToken)
+
+	if h.secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.secret)) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	kind := WebhookKind(r.URL.Query().Get(webhookParamKind))
+	dispatch, status := h.dispatcher(kind)
+
+	switch status {
+	case http.StatusBadRequest:
+		http.Error(w, fmt.Sprintf("unknown webhook kind %q", kind), status)
+		return
+	case http.StatusNotFound:
+		http.Error(w, fmt.Sprintf("no callback for webhook kind %q", kind), status)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+
+		if errors.As(err, &maxErr) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	key := sha256.Sum256(append([]byte(kind+"\n"), body...))
+
+	if !h.reserve(key) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := dispatch(r.Context(), body); err != nil {
+		h.release(key)
+
+		var decodeErr *webhookDecodeError
+
+		if errors.As(err, &decodeErr) {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+
+		http.Error(w, "callback failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookDecodeError is returned by a dispatcher when the body does not decode.
+type webhookDecodeError struct {
+	kind WebhookKind
+	err  error
+}
+
+func (e *webhookDecodeError) Error() string {
+	return fmt.Sprintf("invalid %s callback : %s", e.kind, e.err)
+}
+
+// dispatcher returns the function decoding and dispatching a callback of kind,
+// or the status to answer when there is none.
+func (h *WebhookHandler) dispatcher(kind WebhookKind) (func(ctx context.Context, body []byte) error, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch kind {
+	case WebhookDeployment:
+		return webhookDispatcher(kind, h.onDeployment)
+	case WebhookSession:
+		return webhookDispatcher(kind, h.onSession)
+	case WebhookTelemetry:
+		return webhookDispatcher(kind, h.onTelemetry)
+	}
+
+	return nil, http.StatusBadRequest
+}
+
+func webhookDispatcher[T any](kind WebhookKind, fn func(ctx context.Context, value T) error) (func(ctx context.Context, body []byte) error, int) {
+	if fn == nil {
+		return nil, http.StatusNotFound
+	}
+
+	return func(ctx context.Context, body []byte) error {
+		var value T
+
+		if err := json.Unmarshal(body, &value); err != nil {
+			return &webhookDecodeError{kind: kind, err: err}
+		}
+
+		return fn(ctx, value)
+	}, 0
+}
+
+// reserve marks a callback as seen, reporting false when it already was.
+func (h *WebhookHandler) reserve(key [sha256.Size]byte) bool {
+	if h.dedupSize <= 0 {
+		return true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.seen[key] {
+		return false
+	}
+
+	h.seen[key] = true
+	h.order = append(h.order, key)
+
+	if len(h.order) > h.dedupSize {
+		delete(h.seen, h.order[0])
+		h.order = h.order[1:]
+	}
+
+	return true
+}
+
+// release forgets a callback whose dispatch failed, so its redelivery is dispatched.
+func (h *WebhookHandler) release(key [sha256.Size]byte) {
+	if h.dedupSize <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.seen, key)
+
+	h.order = slices.DeleteFunc(h.order, func(seen [sha256.Size]byte) bool {
+		return seen == key
+	})
+}
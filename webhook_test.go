@@ -0,0 +1,135 @@
+package edgegap_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kisshan13/go-edgegap"
+)
+
+// postWebhook sends body to the handler at target and returns the status code answered.
+func postWebhook(t *testing.T, handler http.Handler, target string, body string) int {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+
+	return recorder.Code
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	handler, err := edgegap.NewWebhookHandler("s3cret")
+	if err != nil {
+		t.Fatalf("NewWebhookHandler() error = %v", err)
+	}
+
+	var deployments []edgegap.DeploymentInfo
+	var sessions []edgegap.Session
+
+	handler.OnDeployment(func(ctx context.Context, info edgegap.DeploymentInfo) error {
+		deployments = append(deployments, info)
+		return nil
+	})
+
+	handler.OnSession(func(ctx context.Context, session edgegap.Session) error {
+		sessions = append(sessions, session)
+		return nil
+	})
+
+	deploymentURL, err := handler.URL("https://game.example.com/edgegap?region=eu", edgegap.WebhookDeployment)
+	if err != nil {
+		t.Fatalf("URL() error = %v", err)
+	}
+
+	if !strings.Contains(deploymentURL, "region=eu") || !strings.Contains(deploymentURL, "token=s3cret") {
+		t.Errorf("URL() = %s, want the base query and the token", deploymentURL)
+	}
+
+	body := `{"request_id":"9f511e17dfa4","current_status":"Status.READY","running":true}`
+
+	if code := postWebhook(t, handler, deploymentURL, body); code != http.StatusNoContent {
+		t.Errorf("deployment callback status = %d, want %d", code, http.StatusNoContent)
+	}
+
+	// Redeliveries are acknowledged without being dispatched again.
+	if code := postWebhook(t, handler, deploymentURL, body); code != http.StatusNoContent {
+		t.Errorf("redelivered callback status = %d, want %d", code, http.StatusNoContent)
+	}
+
+	if len(deployments) != 1 || deployments[0].RequestID != "9f511e17dfa4" || !deployments[0].Running {
+		t.Errorf("dispatched deployments = %+v, want one ready deployment", deployments)
+	}
+
+	sessionURL, _ := handler.URL("https://game.example.com/edgegap", edgegap.WebhookSession)
+
+	if code := postWebhook(t, handler, sessionURL, `{"session_id":"abc","status":"Status.READY","ready":true}`); code != http.StatusNoContent {
+		t.Errorf("session callback status = %d, want %d", code, http.StatusNoContent)
+	}
+
+	if len(sessions) != 1 || sessions[0].ID != "abc" {
+		t.Errorf("dispatched sessions = %+v, want session abc", sessions)
+	}
+}
+
+func TestNewWebhookHandlerEmptySecret(t *testing.T) {
+	if _, err := edgegap.NewWebhookHandler(""); !errors.Is(err, edgegap.ErrEmptyWebhookSecret) {
+		t.Errorf("NewWebhookHandler() error = %v, want ErrEmptyWebhookSecret", err)
+	}
+}
+
+func TestWebhookHandlerStatusCodes(t *testing.T) {
+	handler, err := edgegap.NewWebhookHandler("s3cret", edgegap.WithWebhookMaxBodySize(64))
+	if err != nil {
+		t.Fatalf("NewWebhookHandler() error = %v", err)
+	}
+
+	failures := 0
+
+	handler.OnDeployment(func(ctx context.Context, info edgegap.DeploymentInfo) error {
+		if failures++; failures == 1 {
+			return errors.New("database unavailable")
+		}
+
+		return nil
+	})
+
+	deploymentURL, _ := handler.URL("https://game.example.com/edgegap", edgegap.WebhookDeployment)
+	telemetryURL, _ := handler.URL("https://game.example.com/edgegap", edgegap.WebhookTelemetry)
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		want   int
+	}{
+		{"missing token", "/edgegap?kind=deployment", `{}`, http.StatusUnauthorized},
+		{"wrong token", "/edgegap?kind=deployment&token=wrong", `{}`, http.StatusUnauthorized},
+		{"unknown kind", "/edgegap?kind=fleet&token=s3cret", `{}`, http.StatusBadRequest},
+		{"no callback", telemetryURL, `{}`, http.StatusNotFound},
+		{"invalid body", deploymentURL, `{`, http.StatusBadRequest},
+		{"body too large", deploymentURL, `{"request_id":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+		{"callback error", deploymentURL, `{"request_id":"9f511e17dfa4"}`, http.StatusInternalServerError},
+		{"redelivery after error", deploymentURL, `{"request_id":"9f511e17dfa4"}`, http.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		if code := postWebhook(t, handler, test.target, test.body); code != test.want {
+			t.Errorf("%s: status = %d, want %d", test.name, code, test.want)
+		}
+	}
+
+	if failures != 2 {
+		t.Errorf("callback called %d times, want 2", failures)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, deploymentURL, nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed || recorder.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET status = %d, Allow = %q, want 405 and POST", recorder.Code, recorder.Header().Get("Allow"))
+	}
+}